@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	gookx "okxauto/internal"
+)
+
+// runBacktestCommand implements `okxauto backtest --from ... --to ... --min-diff ... --trade-size ...`.
+// It replays recorded order-book/funding data through the same analyser
+// code path used for live scanning and prints the resulting equity curve,
+// max drawdown, Sharpe ratio, and per-symbol contribution table.
+func runBacktestCommand(args []string) {
+	fs := flag.NewFlagSet("backtest", flag.ExitOnError)
+	from := fs.String("from", "", "Start date (YYYY-MM-DD)")
+	to := fs.String("to", "", "End date (YYYY-MM-DD)")
+	minDiff := fs.Float64("min-diff", 0.24, "Minimum percentage difference to include")
+	tradeSize := fs.Float64("trade-size", 1000.0, "Trade size in USD")
+	dataDir := fs.String("data-dir", "./backtest-data", "Directory containing recorded order-book/funding CSVs")
+	fs.Parse(args)
+
+	fromTime, err := time.Parse("2006-01-02", *from)
+	if err != nil {
+		log.Fatalf("Invalid --from date: %v", err)
+	}
+	toTime, err := time.Parse("2006-01-02", *to)
+	if err != nil {
+		log.Fatalf("Invalid --to date: %v", err)
+	}
+
+	sim, err := gookx.NewSimulatedClient(*dataDir)
+	if err != nil {
+		log.Fatalf("Failed to load simulated client: %v", err)
+	}
+
+	ctx := context.Background()
+	instruments, err := sim.FetchInstruments(ctx, "", "")
+	if err != nil {
+		log.Fatalf("Failed to enumerate recorded instruments: %v", err)
+	}
+
+	// SimulatedClient doesn't track a real InstType per recorded
+	// instrument, so recover margin vs. swap from OKX's own "-SWAP"
+	// suffix naming convention (e.g. BTC-USDT vs. BTC-USDT-SWAP) before
+	// handing the two sides to FindMatchingSymbols.
+	var marginInstruments, swapInstruments []gookx.Instrument
+	for _, inst := range instruments {
+		if strings.HasSuffix(inst.InstID, "-SWAP") {
+			swapInstruments = append(swapInstruments, inst)
+		} else {
+			marginInstruments = append(marginInstruments, inst)
+		}
+	}
+	matches := gookx.FindMatchingSymbols(marginInstruments, swapInstruments)
+
+	tradingConfig := gookx.TradingConfig{
+		TradeSizeUSD:   *tradeSize,
+		OrderBookDepth: 20,
+		MaxSlippage:    1.0,
+	}
+
+	backtester := gookx.NewBacktester(sim, matches, tradingConfig, *minDiff)
+	result, err := backtester.Run(ctx, fromTime, toTime)
+	if err != nil {
+		log.Fatalf("Backtest failed: %v", err)
+	}
+
+	fmt.Printf("\nEquity curve (%d points):\n", len(result.EquityCurve))
+	for _, p := range result.EquityCurve {
+		fmt.Printf("%-25s %12.2f\n", p.Time.Format(time.RFC3339), p.Equity)
+	}
+
+	fmt.Printf("\nMax drawdown: %.2f%%\n", result.MaxDrawdown)
+	fmt.Printf("Sharpe ratio: %.4f\n", result.Sharpe)
+
+	fmt.Printf("\n%-12s %-12s\n", "Symbol", "Profit")
+	fmt.Println(strings.Repeat("-", 25))
+	for _, row := range result.SymbolContribution() {
+		fmt.Printf("%-12s %12.2f\n", row.BaseSymbol, row.Profit)
+	}
+}
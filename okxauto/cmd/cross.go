@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	gookx "okxauto/internal"
+)
+
+// runCrossCommand implements `okxauto cross -exchange-b binance|bybit ...`.
+// It reconciles OKX's instrument list against a second Exchange with
+// FindMatchingSymbolsAcrossExchanges, then scans the matched base symbols
+// with CalculateCrossExchangeArbitrageOpportunities, netting out each
+// venue's withdrawal fee for the asset that would need to move to
+// rebalance inventory.
+func runCrossCommand(args []string) {
+	fs := flag.NewFlagSet("cross", flag.ExitOnError)
+	exchangeBName := fs.String("exchange-b", "binance", "Second exchange to compare against OKX: binance or bybit")
+	quoteCurrency := fs.String("quote", "USDT", "Quote currency")
+	instType := fs.String("inst-type", "SPOT", "Instrument type to reconcile on both venues")
+	tradeSize := fs.Float64("trade-size", 1000.0, "Trade size in USD")
+	minDiff := fs.Float64("min-diff", 0.1, "Minimum net percentage difference to include")
+	timeout := fs.Duration("timeout", 30*time.Second, "HTTP timeout")
+	withdrawalFeesRaw := fs.String("withdrawal-fees", "", "Comma-separated exchange:asset:fee entries (flat, in asset units), e.g. okx:BTC:0.0005,binance:BTC:0.0002")
+	fs.Parse(args)
+
+	config := gookx.Config{
+		QuoteCurrency: *quoteCurrency,
+		HTTPTimeout:   *timeout,
+		UserAgent:     "OKX-Instrument-Analyzer/1.0",
+	}
+
+	var exchangeB gookx.Exchange
+	switch *exchangeBName {
+	case "binance":
+		exchangeB = gookx.NewBinanceClient(config)
+	case "bybit":
+		exchangeB = gookx.NewBybitClient(config)
+	default:
+		log.Fatalf("Unknown -exchange-b %q (want binance or bybit)", *exchangeBName)
+	}
+	exchangeA := gookx.NewHTTPClient(config)
+
+	ctx := context.Background()
+
+	matches, err := gookx.FindMatchingSymbolsAcrossExchanges(ctx, exchangeA, exchangeB, *instType, *instType, *quoteCurrency)
+	if err != nil {
+		log.Fatalf("Failed to reconcile instruments across exchanges: %v", err)
+	}
+	fmt.Printf("Found %d matching symbols between %s and %s\n", len(matches), exchangeA.Name(), exchangeB.Name())
+
+	baseSymbols := make([]string, 0, len(matches))
+	pairsA := make(map[string]gookx.CurrencyPair, len(matches))
+	for _, m := range matches {
+		baseSymbols = append(baseSymbols, m.BaseSymbol)
+		pairsA[m.BaseSymbol] = gookx.NewCurrencyPair(exchangeA.Name(), m.Margin)
+	}
+
+	withdrawalFees, err := parseWithdrawalFees(*withdrawalFeesRaw)
+	if err != nil {
+		log.Fatalf("Invalid -withdrawal-fees: %v", err)
+	}
+	results, err := gookx.CalculateCrossExchangeArbitrageOpportunities(ctx, baseSymbols, *quoteCurrency, exchangeA, exchangeB, *tradeSize, withdrawalFees)
+	if err != nil {
+		log.Fatalf("Failed to calculate cross-exchange opportunities: %v", err)
+	}
+
+	fmt.Printf("\n%-12s %-12s %-12s %-10s %-10s %-12s\n", "Symbol", "PriceA", "PriceB", "Diff%", "NetDiff%", "SizeA")
+	fmt.Println(strings.Repeat("-", 70))
+	for _, r := range results {
+		if r.NetPercentDiff < *minDiff {
+			continue
+		}
+		sizeA := *tradeSize / r.PriceA
+		if pair, ok := pairsA[r.BaseSymbol]; ok {
+			sizeA = pair.RoundAmount(sizeA)
+		}
+		fmt.Printf("%-12s %-12.4f %-12.4f %-10.4f %-10.4f %-12.6f\n", r.BaseSymbol, r.PriceA, r.PriceB, r.PercentDiff, r.NetPercentDiff, sizeA)
+	}
+}
+
+// parseWithdrawalFees parses -withdrawal-fees into a WithdrawalFeeTable.
+// Format: "exchange:asset:fee,exchange:asset:fee,...", e.g.
+// "okx:BTC:0.0005,binance:BTC:0.0002".
+func parseWithdrawalFees(raw string) (gookx.WithdrawalFeeTable, error) {
+	table := gookx.WithdrawalFeeTable{}
+	if raw == "" {
+		return table, nil
+	}
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("malformed entry %q (want exchange:asset:fee)", entry)
+		}
+		fee, err := strconv.ParseFloat(parts[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fee in entry %q: %w", entry, err)
+		}
+		exchange, asset := parts[0], parts[1]
+		if table[exchange] == nil {
+			table[exchange] = make(map[string]float64)
+		}
+		table[exchange][asset] = fee
+	}
+	return table, nil
+}
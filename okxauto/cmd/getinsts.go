@@ -5,6 +5,8 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"os"
+	"strings"
 	"time"
 
 	gookx "okxauto/internal"
@@ -12,6 +14,23 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "backtest" {
+		runBacktestCommand(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "stats" {
+		runStatsCommand(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "cross" {
+		runCrossCommand(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "trade" {
+		runTradeCommand(os.Args[2:])
+		return
+	}
+
 	// Parse command line flags
 	var (
 		marginInstType = flag.String("margin-type", "MARGIN", "Margin instrument type")
@@ -27,6 +46,25 @@ func main() {
 		minLiquidityUSD = flag.Float64("min-liquidity", 10000.0, "Minimum liquidity required in USD")
 		maxSlippage     = flag.Float64("max-slippage", 0.5, "Maximum acceptable slippage in percentage")
 		orderBookDepth  = flag.Int("depth", 20, "Order book depth to fetch")
+		useLiveStream   = flag.Bool("live", false, "Use a live WebSocket order book stream instead of REST snapshots (order book analysis only)")
+		liveWarmup      = flag.Duration("live-warmup", 5*time.Second, "How long to wait for the WebSocket cache to populate before scanning")
+
+		// Triangular arbitrage scanner flags
+		triangular      = flag.Bool("triangular", false, "Run the triangular arbitrage scanner instead of margin/swap analysis")
+		triangularPaths = flag.String("triangular-paths", "", "Semicolon-separated list of colon-separated 3-leg paths, e.g. BTC-USDT:ETH-BTC:ETH-USDT;...")
+		triangularBases = flag.String("triangular-bases", "", "Comma-separated base currencies (e.g. BTC,ETH) to restrict auto-discovered SPOT cycles to; ignored if -triangular-paths is set")
+
+		// Output sink flags
+		output      = flag.String("output", "table", "Output format: table, jsonl, or csv")
+		metricsAddr = flag.String("metrics-addr", "", "If set, also serve Prometheus metrics on this address (e.g. :9108)")
+
+		// PnL model flags (order book analysis path only)
+		holdingHorizon = flag.Duration("holding-horizon", 8*time.Hour, "Assumed holding period for funding/borrow proration in the PnL model")
+		minNetProfit   = flag.Float64("min-net-profit", 0.06, "Minimum NetPnLBreakdown.Net percent to report an order-book opportunity")
+
+		// Persistence flags
+		recordDSN    = flag.String("record-dsn", "", "If set, record the reported top-N mark-price diffs and their funding rates to this Store DSN (e.g. ./okxcct.db); order-book and triangular scans are not recorded")
+		recordDriver = flag.String("record-driver", "sqlite3", "database/sql driver name for -record-dsn (the schema migrations are SQLite-only, so only sqlite3 works)")
 	)
 	flag.Parse()
 
@@ -37,6 +75,12 @@ func main() {
 		QuoteCurrency:  *quoteCurrency,
 		HTTPTimeout:    *timeout,
 		UserAgent:      "OKX-Instrument-Analyzer/1.0",
+		MetricsAddr:    *metricsAddr,
+	}
+
+	sink, metrics, err := newOutputSink(*output, config)
+	if err != nil {
+		log.Fatalf("Invalid -output: %v", err)
 	}
 
 	// Create trading configuration for order book analysis
@@ -51,7 +95,19 @@ func main() {
 	client := gookx.NewHTTPClient(config)
 	ctx := context.Background()
 
-	if *useOrderBook {
+	var store *gookx.SQLStore
+	if *recordDSN != "" {
+		var err error
+		store, err = gookx.NewSQLStore(*recordDriver, *recordDSN)
+		if err != nil {
+			log.Fatalf("Failed to open -record-dsn store: %v", err)
+		}
+		defer store.Close()
+	}
+
+	if *triangular {
+		runTriangularScanner(ctx, client, config, tradingConfig, *triangularPaths, *triangularBases, *minDiff)
+	} else if *useOrderBook {
 		// Use order book analysis
 		fmt.Println("Using order book analysis for real execution prices...")
 		fmt.Printf("Trade size: $%.2f, Min liquidity: $%.2f, Max slippage: %.2f%%, Order book depth: %d\n",
@@ -76,8 +132,16 @@ func main() {
 		fmt.Printf("Found %d matching symbols between %s and %s instruments\n",
 			len(matchingSymbols), config.MarginInstType, config.SwapInstType)
 
+		var source gookx.OrderBookSource = client
+		if *useLiveStream {
+			source = connectLiveOrderBooks(ctx, config, matchingSymbols, *liveWarmup)
+		}
+		if metrics != nil {
+			source = gookx.NewObservingOrderBookSource(source, metrics)
+		}
+
 		// Calculate real arbitrage opportunities using order books
-		realResults, err := gookx.CalculateRealArbitrageOpportunities(matchingSymbols, client, tradingConfig, ctx)
+		realResults, err := gookx.CalculateRealArbitrageOpportunities(matchingSymbols, source, tradingConfig, ctx)
 		if err != nil {
 			log.Fatalf("Failed to calculate real arbitrage opportunities: %v", err)
 		}
@@ -108,22 +172,8 @@ func main() {
 			borrowRates = map[string]float64{}
 		}
 
-		// Calculate fees for each result
-		feesMap := make(map[string]float64)
-		for _, result := range filteredResults {
-			// Create a DiffResult for fee calculation
-			diffResult := gookx.DiffResult{
-				BaseSymbol:    result.BaseSymbol,
-				TermStructure: result.TermStructure,
-			}
-			feesMap[result.BaseSymbol] = gookx.EstimateFees(diffResult, fees, borrowRates)
-		}
-
 		// Fetch funding info for the results
-		fundingMap := make(map[string]struct {
-			Rate          float64
-			TimeToFunding time.Duration
-		})
+		fundingMap := make(map[string]gookx.FundingSummary)
 		for _, result := range filteredResults {
 			// Find the swap instrument ID
 			var swapInstID string
@@ -139,21 +189,18 @@ func main() {
 
 			funding, err := client.FetchFundingInfo(ctx, swapInstID)
 			if err != nil {
-				fundingMap[result.BaseSymbol] = struct {
-					Rate          float64
-					TimeToFunding time.Duration
-				}{0, 0}
+				fundingMap[result.BaseSymbol] = gookx.FundingSummary{Rate: 0, TimeToFunding: 0}
 			} else {
 				tf := time.Until(time.UnixMilli(funding.NextFundingTime))
-				fundingMap[result.BaseSymbol] = struct {
-					Rate          float64
-					TimeToFunding time.Duration
-				}{funding.FundingRate, tf}
+				fundingMap[result.BaseSymbol] = gookx.FundingSummary{Rate: funding.FundingRate, TimeToFunding: tf}
 			}
 		}
 
 		// Print results
-		gookx.PrintRealArbitrageResults(filteredResults, fundingMap, feesMap, *minDiff)
+		model := gookx.NewPnLModel(*holdingHorizon)
+		if err := gookx.PrintRealArbitrageResults(filteredResults, fundingMap, fees, borrowRates, model, *minNetProfit, sink); err != nil {
+			log.Fatalf("Failed to write results: %v", err)
+		}
 
 	} else {
 		// Use original mark price analysis
@@ -216,10 +263,7 @@ func main() {
 		}
 
 		// Fetch funding info for only the top-N symbols and build a map
-		fundingMap := make(map[string]struct {
-			Rate          float64
-			TimeToFunding time.Duration
-		})
+		fundingMap := make(map[string]gookx.FundingSummary)
 		feesMap := make(map[string]float64)
 		for _, d := range diffs {
 			var swapInstID string
@@ -234,20 +278,176 @@ func main() {
 			}
 			funding, err := client.FetchFundingInfo(ctx, swapInstID)
 			if err != nil {
-				fundingMap[d.BaseSymbol] = struct {
-					Rate          float64
-					TimeToFunding time.Duration
-				}{0, 0}
+				fundingMap[d.BaseSymbol] = gookx.FundingSummary{Rate: 0, TimeToFunding: 0}
 			} else {
 				tf := time.Until(time.UnixMilli(funding.NextFundingTime))
-				fundingMap[d.BaseSymbol] = struct {
-					Rate          float64
-					TimeToFunding time.Duration
-				}{funding.FundingRate, tf}
+				fundingMap[d.BaseSymbol] = gookx.FundingSummary{Rate: funding.FundingRate, TimeToFunding: tf}
+				if store != nil {
+					if err := store.RecordFunding(ctx, swapInstID, funding.FundingRate, funding.NextFundingTime); err != nil {
+						log.Printf("Warning: failed to record funding for %s: %v", swapInstID, err)
+					}
+				}
 			}
 			feesMap[d.BaseSymbol] = gookx.EstimateFees(d, fees, borrowRates)
+			if store != nil {
+				if err := store.RecordDiff(ctx, d); err != nil {
+					log.Printf("Warning: failed to record diff for %s: %v", d.BaseSymbol, err)
+				}
+			}
 		}
 
-		gookx.PrintTopMarkPxDiffsWithFundingAndFees(diffs, fundingMap, feesMap, *minDiff)
+		if err := gookx.PrintTopMarkPxDiffsWithFundingAndFees(diffs, fundingMap, feesMap, *minDiff, sink); err != nil {
+			log.Fatalf("Failed to write results: %v", err)
+		}
+	}
+}
+
+// newOutputSink builds the OutputSink selected by -output, additionally
+// fanning out to a PrometheusSink when -metrics-addr is set. The
+// PrometheusSink is also returned on its own (nil if -metrics-addr is
+// unset) so callers can wrap an OrderBookSource to observe fetch latency.
+func newOutputSink(kind string, config gookx.Config) (gookx.OutputSink, *gookx.PrometheusSink, error) {
+	var base gookx.OutputSink
+	switch kind {
+	case "table":
+		base = gookx.NewTableSink(os.Stdout)
+	case "jsonl":
+		base = gookx.NewJSONLSink(os.Stdout)
+	case "csv":
+		base = gookx.NewCSVSink(os.Stdout)
+	default:
+		return nil, nil, fmt.Errorf("unknown output format %q (want table, jsonl, or csv)", kind)
+	}
+	if config.MetricsAddr == "" {
+		return base, nil, nil
+	}
+	metrics := gookx.NewPrometheusSink(config.MetricsAddr)
+	return gookx.NewMultiSink(base, metrics), metrics, nil
+}
+
+// parseTriangularPaths parses the -triangular-paths flag into TriangularPath
+// values. Format: "BTC-USDT:ETH-BTC:ETH-USDT;SOL-USDT:SOL-BTC:BTC-USDT".
+func parseTriangularPaths(raw string) []gookx.TriangularPath {
+	var paths []gookx.TriangularPath
+	for _, group := range strings.Split(raw, ";") {
+		group = strings.TrimSpace(group)
+		if group == "" {
+			continue
+		}
+		legs := strings.Split(group, ":")
+		if len(legs) != 3 {
+			log.Printf("Warning: skipping malformed triangular path %q", group)
+			continue
+		}
+		paths = append(paths, gookx.TriangularPath{legs[0], legs[1], legs[2]})
+	}
+	return paths
+}
+
+func runTriangularScanner(ctx context.Context, client *gookx.HTTPClient, config gookx.Config, tradingConfig gookx.TradingConfig, rawPaths, rawBases string, minDiff float64) {
+	fmt.Println("Running triangular arbitrage scanner...")
+
+	fees, err := client.FetchFeeInfo(ctx)
+	if err != nil {
+		log.Fatalf("Failed to fetch fee info: %v", err)
+	}
+	scanner := gookx.NewTriangularScanner(client, fees, tradingConfig.TradeSizeUSD, tradingConfig.OrderBookDepth)
+
+	paths := parseTriangularPaths(rawPaths)
+	bases := parseTriangularBases(rawBases)
+
+	if len(paths) == 0 && len(bases) > 0 {
+		fmt.Printf("No paths supplied via -triangular-paths, scanning SPOT cycles touching %v...\n", bases)
+		spotInstruments, err := client.FetchInstruments(ctx, "SPOT", "")
+		if err != nil {
+			log.Fatalf("Failed to fetch SPOT instruments: %v", err)
+		}
+		opportunities, err := scanner.ScanSpotBases(ctx, bases, spotInstruments)
+		if err != nil {
+			log.Fatalf("Failed to scan triangular paths: %v", err)
+		}
+		minNetProfit := minDiff
+		fmt.Printf("\n%-40s %-15s %-12s\n", "Path", "RoundTrip", "NetProfit")
+		fmt.Println(strings.Repeat("-", 70))
+		for _, o := range opportunities {
+			if o.NetProfit < minNetProfit {
+				continue
+			}
+			pathStr := fmt.Sprintf("%s->%s->%s", o.Path[0], o.Path[1], o.Path[2])
+			fmt.Printf("%-40s %-15.6f %-12.4f%%\n", pathStr, o.RoundTripReturn, o.NetProfit)
+		}
+		return
+	}
+
+	if len(paths) == 0 {
+		fmt.Println("No paths supplied via -triangular-paths or -triangular-bases, auto-discovering from SPOT instruments...")
+		spotInstruments, err := client.FetchInstruments(ctx, "SPOT", "")
+		if err != nil {
+			log.Fatalf("Failed to fetch SPOT instruments: %v", err)
+		}
+		paths = gookx.DiscoverTriangularPaths(spotInstruments)
+		fmt.Printf("Discovered %d candidate triangular paths\n", len(paths))
+	}
+
+	results, err := scanner.Scan(ctx, paths)
+	if err != nil {
+		log.Fatalf("Failed to scan triangular paths: %v", err)
+	}
+
+	minRatio := 1 + minDiff/100
+	fmt.Printf("\n%-40s %-10s %-10s %-12s %-12s\n", "Path", "NetRatio", "Direction", "MinLiquid", "Slippage")
+	fmt.Println(strings.Repeat("-", 90))
+	for _, r := range results {
+		if r.NetRatio < minRatio {
+			continue
+		}
+		pathStr := fmt.Sprintf("%s->%s->%s", r.Path[0], r.Path[1], r.Path[2])
+		fmt.Printf("%-40s %-10.6f %-10s %-12.2f %-12.4f%%\n", pathStr, r.NetRatio, r.Direction, r.MinLiquidity, r.TotalSlippage)
+	}
+}
+
+// connectLiveOrderBooks dials OKX's public WebSocket, subscribes to every
+// margin and swap instrument in matchingSymbols, and waits up to warmup for
+// the cache to populate before returning the WSClient as an OrderBookSource.
+func connectLiveOrderBooks(ctx context.Context, config gookx.Config, matchingSymbols []gookx.MatchingSymbol, warmup time.Duration) *gookx.WSClient {
+	fmt.Println("Connecting to live WebSocket order book stream...")
+	instIDs := make([]string, 0, len(matchingSymbols)*2)
+	for _, m := range matchingSymbols {
+		instIDs = append(instIDs, m.Margin.InstID, m.Swap.InstID)
+	}
+
+	ws := gookx.NewWSClient(config)
+	if err := ws.Connect(ctx, instIDs); err != nil {
+		log.Fatalf("Failed to connect to WebSocket stream: %v", err)
+	}
+
+	deadline := time.Now().Add(warmup)
+	for time.Now().Before(deadline) {
+		ready := true
+		for _, instID := range instIDs {
+			if ws.LiveOrderBook(instID) == nil {
+				ready = false
+				break
+			}
+		}
+		if ready {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	return ws
+}
+
+// parseTriangularBases parses the -triangular-bases flag into a list of
+// base currencies, e.g. "BTC,ETH" -> ["BTC", "ETH"].
+func parseTriangularBases(raw string) []string {
+	var bases []string
+	for _, b := range strings.Split(raw, ",") {
+		b = strings.TrimSpace(b)
+		if b != "" {
+			bases = append(bases, b)
+		}
 	}
+	return bases
 }
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	gookx "okxauto/internal"
+)
+
+// runStatsCommand implements `okxauto stats --symbol BTC --from ... --to ... --min-diff ...`.
+// It computes per-symbol hit rate and mean/median spread from recorded
+// history so -min-diff can be picked from an empirical distribution.
+func runStatsCommand(args []string) {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	dsn := fs.String("dsn", "./okxcct.db", "Store DSN (SQLite file path by default)")
+	driver := fs.String("driver", "sqlite3", "database/sql driver name (the schema migrations are SQLite-only, so only sqlite3 works)")
+	symbol := fs.String("symbol", "", "Base symbol to summarize")
+	from := fs.String("from", "", "Start date (YYYY-MM-DD)")
+	to := fs.String("to", "", "End date (YYYY-MM-DD)")
+	minDiff := fs.Float64("min-diff", 0.24, "Minimum percentage difference considered a hit")
+	fs.Parse(args)
+
+	if *symbol == "" {
+		log.Fatal("-symbol is required")
+	}
+
+	fromTime, err := time.Parse("2006-01-02", *from)
+	if err != nil {
+		log.Fatalf("Invalid --from date: %v", err)
+	}
+	toTime, err := time.Parse("2006-01-02", *to)
+	if err != nil {
+		log.Fatalf("Invalid --to date: %v", err)
+	}
+
+	store, err := gookx.NewSQLStore(*driver, *dsn)
+	if err != nil {
+		log.Fatalf("Failed to open store: %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	stats, err := store.SymbolStats(ctx, *symbol, fromTime, toTime, *minDiff)
+	if err != nil {
+		log.Fatalf("Failed to compute symbol stats: %v", err)
+	}
+
+	fmt.Printf("\n%s (%s to %s)\n", stats.BaseSymbol, *from, *to)
+	fmt.Printf("Samples:      %d\n", stats.SampleCount)
+	fmt.Printf("Hit rate:     %.2f%% (>= %.2f%%)\n", stats.HitRate, *minDiff)
+	fmt.Printf("Mean spread:  %.4f%%\n", stats.MeanSpread)
+	fmt.Printf("Median spread: %.4f%%\n", stats.MedianSpread)
+}
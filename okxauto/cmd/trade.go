@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	gookx "okxauto/internal"
+)
+
+// runTradeCommand implements `okxauto trade --live-trading --engine trader|executor ...`.
+// It scans real margin/swap arbitrage opportunities and hands the best one
+// above -min-diff to an execution engine: Trader (borrow-aware, market
+// orders, the default; rolls back the margin leg only when the swap leg
+// cannot be placed) or Executor (configurable order type; rolls back when
+// the swap leg cannot be placed or does not fill within -rollback-timeout).
+// With -engine=trader, orders are dry-run (logged, not sent) unless
+// -live-trading is passed; -engine=executor refuses to run without it.
+func runTradeCommand(args []string) {
+	fs := flag.NewFlagSet("trade", flag.ExitOnError)
+	marginInstType := fs.String("margin-type", "MARGIN", "Margin instrument type")
+	swapInstType := fs.String("swap-type", "SWAP", "Swap instrument type")
+	quoteCurrency := fs.String("quote", "USDT", "Quote currency")
+	minDiff := fs.Float64("min-diff", 0.24, "Minimum percentage difference required to trade")
+	tradeSizeUSD := fs.Float64("trade-size", 1000.0, "Trade size in USD")
+	minLiquidityUSD := fs.Float64("min-liquidity", 10000.0, "Minimum liquidity required in USD")
+	maxSlippage := fs.Float64("max-slippage", 0.5, "Maximum acceptable slippage in percentage")
+	orderBookDepth := fs.Int("depth", 20, "Order book depth to fetch")
+	liveTrading := fs.Bool("live-trading", false, "Actually send orders to OKX instead of dry-run logging")
+	engine := fs.String("engine", "trader", "Execution engine: trader (borrow-aware, market orders) or executor (configurable order type, fill-timeout rollback)")
+	ordType := fs.String("ord-type", gookx.OrdTypeIOC, "Order type for -engine=executor")
+	rollbackTimeout := fs.Duration("rollback-timeout", 5*time.Second, "How long -engine=executor waits for the swap leg to fill before rolling back")
+	timeout := fs.Duration("timeout", 30*time.Second, "HTTP timeout")
+	fs.Parse(args)
+
+	config := gookx.Config{
+		MarginInstType: *marginInstType,
+		SwapInstType:   *swapInstType,
+		QuoteCurrency:  *quoteCurrency,
+		HTTPTimeout:    *timeout,
+		UserAgent:      "OKX-Instrument-Analyzer/1.0",
+		LiveTrading:    *liveTrading,
+	}
+	tradingConfig := gookx.TradingConfig{
+		TradeSizeUSD:    *tradeSizeUSD,
+		MinLiquidityUSD: *minLiquidityUSD,
+		MaxSlippage:     *maxSlippage,
+		OrderBookDepth:  *orderBookDepth,
+	}
+
+	client := gookx.NewHTTPClient(config)
+	ctx := context.Background()
+
+	marginInstruments, err := client.FetchInstruments(ctx, config.MarginInstType, config.QuoteCurrency)
+	if err != nil {
+		log.Fatalf("Failed to fetch margin instruments: %v", err)
+	}
+	swapInstruments, err := client.FetchInstruments(ctx, config.SwapInstType, "")
+	if err != nil {
+		log.Fatalf("Failed to fetch swap instruments: %v", err)
+	}
+	matchingSymbols := gookx.FindMatchingSymbols(marginInstruments, swapInstruments)
+
+	opportunities, err := gookx.CalculateRealArbitrageOpportunities(matchingSymbols, client, tradingConfig, ctx)
+	if err != nil {
+		log.Fatalf("Failed to calculate real arbitrage opportunities: %v", err)
+	}
+
+	var best *gookx.RealArbitrageResult
+	for i := range opportunities {
+		if opportunities[i].PercentDiff >= *minDiff && opportunities[i].HasEnoughLiquidity {
+			best = &opportunities[i]
+			break
+		}
+	}
+	if best == nil {
+		fmt.Printf("No opportunity found above -min-diff=%.4f%%\n", *minDiff)
+		return
+	}
+	fmt.Printf("Trading %s: %.4f%% diff (%s)\n", best.BaseSymbol, best.PercentDiff, best.TermStructure)
+
+	var marginInstID, swapInstID string
+	for _, match := range matchingSymbols {
+		if match.BaseSymbol == best.BaseSymbol {
+			marginInstID, swapInstID = match.Margin.InstID, match.Swap.InstID
+			break
+		}
+	}
+
+	switch *engine {
+	case "trader":
+		trader := gookx.NewTrader(client, config, tradingConfig)
+		interestRates, err := client.FetchInterestRates(ctx)
+		if err != nil {
+			log.Printf("Warning: could not fetch borrow rates, will use none: %v", err)
+			interestRates = map[string]float64{}
+		}
+		baseCcy := gookx.ExtractBaseSymbol(marginInstID)
+		if err := trader.ExecuteOpportunity(ctx, *best, marginInstID, swapInstID, baseCcy, interestRates); err != nil {
+			log.Fatalf("Trader failed to execute opportunity: %v", err)
+		}
+	case "executor":
+		if !*liveTrading {
+			log.Fatal("-engine=executor has no dry-run mode; pass -live-trading to confirm you want to send real orders")
+		}
+		marginPrice := best.MarginBuyPrice
+		if !best.IsContango {
+			marginPrice = best.MarginSellPrice
+		}
+		sz := *tradeSizeUSD / marginPrice
+		executor := gookx.NewExecutor(client, *ordType)
+		if _, err := executor.Execute(ctx, *best, marginInstID, swapInstID, sz, *rollbackTimeout); err != nil {
+			log.Fatalf("Executor failed to execute opportunity: %v", err)
+		}
+	default:
+		log.Fatalf("Unknown -engine %q (want trader or executor)", *engine)
+	}
+
+	fmt.Println("Done.")
+}
@@ -113,10 +113,7 @@ func CalculateTopMarkPxDiffs(matches []MatchingSymbol, topN int, minDiff float64
 	return diffs, nil
 }
 
-func PrintTopMarkPxDiffsWithFunding(diffs []DiffResult, fundingMap map[string]struct {
-	Rate          float64
-	TimeToFunding time.Duration
-}, minDiff float64) {
+func PrintTopMarkPxDiffsWithFunding(diffs []DiffResult, fundingMap map[string]FundingSummary, minDiff float64) {
 	if minDiff > 0 {
 		fmt.Printf("\nTop %d symbols with %% markPx difference >= %.2f%% (swap vs margin):\n", len(diffs), minDiff)
 	} else {
@@ -136,33 +133,23 @@ func PrintTopMarkPxDiffsWithFunding(diffs []DiffResult, fundingMap map[string]st
 	}
 }
 
-func PrintTopMarkPxDiffsWithFundingAndFees(diffs []DiffResult, fundingMap map[string]struct {
-	Rate          float64
-	TimeToFunding time.Duration
-}, feesMap map[string]float64, minDiff float64) {
-	if minDiff > 0 {
-		fmt.Printf("\nTop %d symbols with %% markPx difference >= %.2f%% (swap vs margin):\n", len(diffs), minDiff)
-	} else {
-		fmt.Printf("\nTop %d symbols by %% markPx difference (swap vs margin):\n", len(diffs))
-	}
-	fmt.Printf("%-12s %-15s %-15s %-18s %-10s %-15s %-12s %-16s %-10s %-12s\n", "Symbol", "Margin", "Swap", "Actual Diff", "% Diff", "Structure", "FundingRate", "TimeToFunding", "Fees", "ActualProfit")
-	fmt.Println(strings.Repeat("-", 152))
+// PrintTopMarkPxDiffsWithFundingAndFees renders mark-price diff rows
+// through sink (TableSink reproduces the original stdout table; JSONLSink,
+// CSVSink, and PrometheusSink are also available) instead of calling
+// fmt.Printf directly.
+func PrintTopMarkPxDiffsWithFundingAndFees(diffs []DiffResult, fundingMap map[string]FundingSummary, feesMap map[string]float64, minDiff float64, sink OutputSink) error {
+	var rows []DiffRow
 	for _, d := range diffs {
 		funding := fundingMap[d.BaseSymbol]
 		fees := feesMap[d.BaseSymbol]
-		diffSign := "+"
-		if d.ActualDiff < 0 {
-			diffSign = ""
-		}
-		actualDiffStr := fmt.Sprintf("%s%.6f", diffSign, d.ActualDiff)
 		feesPct := fees * 100
 		actualProfit := d.PercentDiff - feesPct
 		if actualProfit < 0.06 {
-			continue // skip if less than 0.1%%
+			continue // skip if less than 0.06% profit
 		}
-		fmt.Printf("%-12s %-15.6f %-15.6f %-18s %-10.2f %-15s %-12.6f %-16s %-9.4f%%   %-10.2f%%\n",
-			d.BaseSymbol, d.MarginMarkPx, d.SwapMarkPx, actualDiffStr, d.PercentDiff, d.TermStructure, funding.Rate, funding.TimeToFunding.Round(time.Second), feesPct, actualProfit)
+		rows = append(rows, DiffRow{Diff: d, Funding: funding, FeesPercent: feesPct, ActualProfit: actualProfit})
 	}
+	return sink.WriteDiffs(rows, minDiff)
 }
 
 func EstimateFees(diff DiffResult, fees FeeInfo, borrowRates map[string]float64) float64 {
@@ -178,19 +165,21 @@ func EstimateFees(diff DiffResult, fees FeeInfo, borrowRates map[string]float64)
 	return fee
 }
 
-// Calculate real arbitrage opportunities using order book data
-func CalculateRealArbitrageOpportunities(matches []MatchingSymbol, client *HTTPClient, config TradingConfig, ctx context.Context) ([]RealArbitrageResult, error) {
+// Calculate real arbitrage opportunities using order book data. source may
+// be an HTTPClient (REST snapshot) or a WSClient (live streaming cache) —
+// anything satisfying OrderBookSource.
+func CalculateRealArbitrageOpportunities(matches []MatchingSymbol, source OrderBookSource, config TradingConfig, ctx context.Context) ([]RealArbitrageResult, error) {
 	var results []RealArbitrageResult
 
 	for _, match := range matches {
 		// Fetch order books for both instruments
-		marginOrderBook, err := client.FetchOrderBook(ctx, match.Margin.InstID, config.OrderBookDepth)
+		marginOrderBook, err := source.FetchOrderBook(ctx, match.Margin.InstID, config.OrderBookDepth)
 		if err != nil {
 			fmt.Printf("Warning: failed to fetch margin order book for %s: %v\n", match.BaseSymbol, err)
 			continue
 		}
 
-		swapOrderBook, err := client.FetchOrderBook(ctx, match.Swap.InstID, config.OrderBookDepth)
+		swapOrderBook, err := source.FetchOrderBook(ctx, match.Swap.InstID, config.OrderBookDepth)
 		if err != nil {
 			fmt.Printf("Warning: failed to fetch swap order book for %s: %v\n", match.BaseSymbol, err)
 			continue
@@ -304,38 +293,25 @@ func CalculateRealArbitrageOpportunities(matches []MatchingSymbol, client *HTTPC
 	return results, nil
 }
 
-// Print real arbitrage results with order book data
-func PrintRealArbitrageResults(results []RealArbitrageResult, fundingMap map[string]struct {
-	Rate          float64
-	TimeToFunding time.Duration
-}, feesMap map[string]float64, minDiff float64) {
-	if minDiff > 0 {
-		fmt.Printf("\nTop %d symbols with real execution prices (%% difference >= %.2f%%):\n", len(results), minDiff)
-	} else {
-		fmt.Printf("\nTop %d symbols with real execution prices:\n", len(results))
-	}
-
-	fmt.Printf("%-12s %-15s %-15s %-15s %-15s %-10s %-15s %-12s %-16s %-10s %-12s %-12s\n",
-		"Symbol", "MarginBuy", "MarginSell", "SwapBuy", "SwapSell", "% Diff", "Structure", "FundingRate", "TimeToFunding", "Fees", "ActualProfit", "Slippage")
-	fmt.Println(strings.Repeat("-", 180))
-
+// PrintRealArbitrageResults renders real-execution-price rows through
+// sink instead of calling fmt.Printf directly. minNetProfit is the
+// config-driven floor on NetPnLBreakdown.Net that replaces the previous
+// hard-coded 0.06% cutoff.
+func PrintRealArbitrageResults(results []RealArbitrageResult, fundingMap map[string]FundingSummary, fees FeeInfo, borrowRates map[string]float64, model PnLModel, minNetProfit float64, sink OutputSink) error {
+	var rows []RealResultRow
 	for _, r := range results {
 		funding := fundingMap[r.BaseSymbol]
-		fees := feesMap[r.BaseSymbol]
-
-		feesPct := fees * 100
-		actualProfit := r.PercentDiff - feesPct
+		breakdown := model.Estimate(r, funding, fees, borrowRates)
 
-		// Calculate average slippage
-		avgSlippage := (r.MarginSlippage + r.SwapSlippage) / 2
-
-		if actualProfit < 0.06 {
-			continue // skip if less than 0.06% profit
+		if breakdown.Net < minNetProfit {
+			continue
 		}
 
-		fmt.Printf("%-12s %-15.6f %-15.6f %-15.6f %-15.6f %-10.2f %-15s %-12.6f %-16s %-9.4f%%   %-10.2f%%   %-10.4f%%\n",
-			r.BaseSymbol, r.MarginBuyPrice, r.MarginSellPrice, r.SwapBuyPrice, r.SwapSellPrice,
-			r.PercentDiff, r.TermStructure, funding.Rate, funding.TimeToFunding.Round(time.Second),
-			feesPct, actualProfit, avgSlippage)
+		rows = append(rows, RealResultRow{
+			Result:    r,
+			Funding:   funding,
+			Breakdown: breakdown,
+		})
 	}
+	return sink.WriteRealResults(rows, minNetProfit)
 }
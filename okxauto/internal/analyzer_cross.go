@@ -0,0 +1,89 @@
+package gookx
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// CalculateCrossExchangeArbitrageOpportunities compares order book mid
+// prices for the same base/quote pair across two Exchange instances and
+// nets out each venue's withdrawal fee for the asset that would have to
+// move to rebalance inventory between them. Instruments are always named
+// as the spot pair (OKX "BASE-QUOTE", otherwise "BASEQUOTE"), so swap
+// instruments are not compared. tradeSize is the trade notional in the
+// quote currency.
+func CalculateCrossExchangeArbitrageOpportunities(
+	ctx context.Context,
+	baseSymbols []string,
+	quoteCcy string,
+	exchangeA, exchangeB Exchange,
+	tradeSize float64,
+	withdrawalFees WithdrawalFeeTable,
+) ([]CrossExchangeArbitrageResult, error) {
+	var mapper SymbolMapper
+	var results []CrossExchangeArbitrageResult
+
+	for _, base := range baseSymbols {
+		instA := mapper.ToOKX(base, quoteCcy)
+		instB := mapper.ToBinance(base, quoteCcy)
+		if exchangeA.Name() != "okx" {
+			instA, instB = instB, instA
+		}
+
+		bookA, err := exchangeA.FetchOrderBook(ctx, instA, 20)
+		if err != nil {
+			continue
+		}
+		bookB, err := exchangeB.FetchOrderBook(ctx, instB, 20)
+		if err != nil {
+			continue
+		}
+
+		priceA, err := midPrice(bookA)
+		if err != nil {
+			continue
+		}
+		priceB, err := midPrice(bookB)
+		if err != nil {
+			continue
+		}
+
+		actualDiff := priceB - priceA
+		meanPx := (priceA + priceB) / 2
+		percentDiff := 100 * math.Abs(actualDiff) / meanPx
+
+		withdrawalCost := withdrawalFees.Fee(exchangeA.Name(), base)*priceA + withdrawalFees.Fee(exchangeB.Name(), base)*priceB
+		withdrawalCostPercent := 100 * withdrawalCost / tradeSize // as a percent of notional
+		netPercentDiff := percentDiff - withdrawalCostPercent
+
+		if netPercentDiff <= 0 {
+			continue
+		}
+
+		results = append(results, CrossExchangeArbitrageResult{
+			BaseSymbol:     base,
+			ExchangeA:      exchangeA.Name(),
+			ExchangeB:      exchangeB.Name(),
+			PriceA:         priceA,
+			PriceB:         priceB,
+			PercentDiff:    percentDiff,
+			WithdrawalCost: withdrawalCost,
+			NetPercentDiff: netPercentDiff,
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].NetPercentDiff > results[j].NetPercentDiff
+	})
+
+	return results, nil
+}
+
+func midPrice(book *OrderBook) (float64, error) {
+	if len(book.Bids) == 0 || len(book.Asks) == 0 {
+		return 0, fmt.Errorf("order book %s has an empty side", book.InstID)
+	}
+	return (book.Bids[0].Price + book.Asks[0].Price) / 2, nil
+}
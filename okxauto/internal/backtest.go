@@ -0,0 +1,175 @@
+package gookx
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+)
+
+// EquityPoint is one sample of a Backtester's equity curve.
+type EquityPoint struct {
+	Time   time.Time `json:"time"`
+	Equity float64   `json:"equity"`
+}
+
+// BacktestResult summarizes a Backtester run: the equity curve plus the
+// derived risk/return stats an operator would tune TradingConfig against.
+type BacktestResult struct {
+	EquityCurve     []EquityPoint      `json:"equityCurve"`
+	MaxDrawdown     float64            `json:"maxDrawdown"` // percent
+	Sharpe          float64            `json:"sharpe"`       // annualized, assumes hourly steps
+	PerSymbolProfit map[string]float64 `json:"perSymbolProfit"`
+}
+
+// Backtester replays recorded order-book and funding data through
+// CalculateRealArbitrageOpportunities at an hourly step, simulating fills
+// against the recorded books and accruing funding payments and borrow
+// interest the same way a live Trader would.
+type Backtester struct {
+	sim           *SimulatedClient
+	matches       []MatchingSymbol
+	tradingConfig TradingConfig
+	minDiff       float64
+}
+
+func NewBacktester(sim *SimulatedClient, matches []MatchingSymbol, tradingConfig TradingConfig, minDiff float64) *Backtester {
+	return &Backtester{sim: sim, matches: matches, tradingConfig: tradingConfig, minDiff: minDiff}
+}
+
+// Run steps hourly from `from` to `to`, opening a notionally-sized position
+// in every opportunity seen above minDiff and closing it at the next step,
+// then returns the accumulated equity curve and summary stats.
+func (b *Backtester) Run(ctx context.Context, from, to time.Time) (BacktestResult, error) {
+	var result BacktestResult
+	result.PerSymbolProfit = make(map[string]float64)
+
+	equity := 0.0
+	step := time.Hour
+	borrowHours := step.Hours()
+
+	for ts := from; ts.Before(to); ts = ts.Add(step) {
+		b.sim.SetClock(ts.Unix())
+
+		opportunities, err := CalculateRealArbitrageOpportunities(b.matches, b.sim, b.tradingConfig, ctx)
+		if err != nil {
+			continue
+		}
+
+		for _, opp := range opportunities {
+			if opp.PercentDiff < b.minDiff {
+				continue
+			}
+
+			fees, _ := b.sim.FetchFeeInfo(ctx)
+			feeCost := fees.SpotTaker*2 + fees.SwapTaker*2
+
+			borrowCost := 0.0
+			if !opp.IsContango {
+				borrowCost = fees.MarginBorrow * borrowHours
+			}
+
+			// Funding is received in contango (short swap) and paid in
+			// backwardation (long swap), same sign convention as
+			// PnLModel.Estimate.
+			fundingCost := 0.0
+			swapInstID := ""
+			for _, m := range b.matches {
+				if m.BaseSymbol == opp.BaseSymbol {
+					swapInstID = m.Swap.InstID
+					break
+				}
+			}
+			if funding, err := b.sim.FetchFundingInfo(ctx, swapInstID); err == nil {
+				if opp.IsContango {
+					fundingCost = -funding.FundingRate
+				} else {
+					fundingCost = funding.FundingRate
+				}
+			}
+
+			netPercent := opp.PercentDiff - feeCost*100 - borrowCost*100 - fundingCost*100
+			profit := b.tradingConfig.TradeSizeUSD * netPercent / 100
+
+			equity += profit
+			result.PerSymbolProfit[opp.BaseSymbol] += profit
+		}
+
+		result.EquityCurve = append(result.EquityCurve, EquityPoint{Time: ts, Equity: equity})
+	}
+
+	result.MaxDrawdown = maxDrawdown(result.EquityCurve)
+	result.Sharpe = sharpeRatio(result.EquityCurve)
+
+	return result, nil
+}
+
+func maxDrawdown(curve []EquityPoint) float64 {
+	if len(curve) == 0 {
+		return 0
+	}
+	peak := curve[0].Equity
+	maxDD := 0.0
+	for _, p := range curve {
+		if p.Equity > peak {
+			peak = p.Equity
+		}
+		if peak == 0 {
+			continue
+		}
+		dd := (peak - p.Equity) / math.Abs(peak) * 100
+		if dd > maxDD {
+			maxDD = dd
+		}
+	}
+	return maxDD
+}
+
+func sharpeRatio(curve []EquityPoint) float64 {
+	if len(curve) < 2 {
+		return 0
+	}
+	var returns []float64
+	for i := 1; i < len(curve); i++ {
+		returns = append(returns, curve[i].Equity-curve[i-1].Equity)
+	}
+
+	mean := 0.0
+	for _, r := range returns {
+		mean += r
+	}
+	mean /= float64(len(returns))
+
+	variance := 0.0
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	variance /= float64(len(returns))
+	stddev := math.Sqrt(variance)
+	if stddev == 0 {
+		return 0
+	}
+
+	// Annualize assuming hourly steps (24 * 365 periods/year).
+	return mean / stddev * math.Sqrt(24*365)
+}
+
+// SymbolContribution renders PerSymbolProfit sorted by contribution,
+// largest first, for the backtest CLI's summary table.
+func (r BacktestResult) SymbolContribution() []struct {
+	BaseSymbol string
+	Profit     float64
+} {
+	var rows []struct {
+		BaseSymbol string
+		Profit     float64
+	}
+	for symbol, profit := range r.PerSymbolProfit {
+		rows = append(rows, struct {
+			BaseSymbol string
+			Profit     float64
+		}{BaseSymbol: symbol, Profit: profit})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Profit > rows[j].Profit })
+	return rows
+}
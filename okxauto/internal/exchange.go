@@ -0,0 +1,98 @@
+package gookx
+
+import "context"
+
+// Exchange is the minimal surface CalculateRealArbitrageOpportunities and
+// friends need from a venue. HTTPClient (OKX) satisfies this interface
+// directly; BinanceClient is a second implementation so opportunities can
+// be scanned across venues, not just within OKX.
+type Exchange interface {
+	Name() string
+	FetchInstruments(ctx context.Context, instType, quoteCcy string) ([]Instrument, error)
+	FetchOrderBook(ctx context.Context, instID string, depth int) (*OrderBook, error)
+	FetchFundingInfo(ctx context.Context, instID string) (FundingInfo, error)
+	FetchFeeInfo(ctx context.Context) (FeeInfo, error)
+	FetchInterestRates(ctx context.Context) (map[string]float64, error)
+}
+
+// Name identifies the HTTPClient's venue for Exchange-keyed maps like
+// withdrawal fee tables.
+func (h *HTTPClient) Name() string {
+	return "okx"
+}
+
+// SymbolMapper reconciles each venue's instrument ID convention (OKX's
+// "BTC-USDT" vs Binance's "BTCUSDT") against a common base/quote pair.
+type SymbolMapper struct{}
+
+// ToOKX renders a base/quote pair in OKX's dash-separated convention.
+func (SymbolMapper) ToOKX(base, quote string) string {
+	return base + "-" + quote
+}
+
+// ToBinance renders a base/quote pair in Binance's concatenated convention.
+func (SymbolMapper) ToBinance(base, quote string) string {
+	return base + quote
+}
+
+// SplitOKX splits an OKX instrument ID ("BTC-USDT" or "BTC-USDT-SWAP")
+// back into its base and quote currencies.
+func (SymbolMapper) SplitOKX(instID string) (base, quote string) {
+	parts := splitInstID(instID)
+	if len(parts) < 2 {
+		return instID, ""
+	}
+	return parts[0], parts[1]
+}
+
+func splitInstID(instID string) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(instID); i++ {
+		if instID[i] == '-' {
+			parts = append(parts, instID[start:i])
+			start = i + 1
+		}
+	}
+	parts = append(parts, instID[start:])
+	return parts
+}
+
+// FindMatchingSymbolsAcrossExchanges fetches instruments from two Exchange
+// values and reconciles them into MatchingSymbol pairs by each
+// instrument's BaseCcy, since instrument IDs differ between venues
+// ("BTC-USDT" vs "BTCUSDT"). Margin holds exchangeA's instrument and Swap
+// exchangeB's. Instruments with an empty BaseCcy are not matched.
+func FindMatchingSymbolsAcrossExchanges(ctx context.Context, exchangeA, exchangeB Exchange, instTypeA, instTypeB, quoteCcy string) ([]MatchingSymbol, error) {
+	instrumentsA, err := exchangeA.FetchInstruments(ctx, instTypeA, quoteCcy)
+	if err != nil {
+		return nil, err
+	}
+	instrumentsB, err := exchangeB.FetchInstruments(ctx, instTypeB, quoteCcy)
+	if err != nil {
+		return nil, err
+	}
+	byBase := make(map[string]Instrument, len(instrumentsB))
+	for _, inst := range instrumentsB {
+		byBase[inst.BaseCcy] = inst
+	}
+	var matches []MatchingSymbol
+	for _, inst := range instrumentsA {
+		if instB, ok := byBase[inst.BaseCcy]; ok && inst.BaseCcy != "" {
+			matches = append(matches, MatchingSymbol{BaseSymbol: inst.BaseCcy, Margin: inst, Swap: instB})
+		}
+	}
+	return matches, nil
+}
+
+// WithdrawalFeeTable is a per-exchange, per-asset flat withdrawal fee in
+// asset units, used to discount cross-exchange opportunities that would
+// require rebalancing inventory between venues.
+type WithdrawalFeeTable map[string]map[string]float64
+
+func (t WithdrawalFeeTable) Fee(exchange, asset string) float64 {
+	if fees, ok := t[exchange]; ok {
+		return fees[asset]
+	}
+	return 0
+}
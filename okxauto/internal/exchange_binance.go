@@ -0,0 +1,197 @@
+package gookx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// BinanceClient is a second Exchange implementation so opportunities can be
+// scanned OKX-vs-Binance, not just within OKX.
+type BinanceClient struct {
+	client *http.Client
+	config Config
+}
+
+func NewBinanceClient(config Config) *BinanceClient {
+	return &BinanceClient{
+		client: &http.Client{Timeout: config.HTTPTimeout},
+		config: config,
+	}
+}
+
+func (b *BinanceClient) Name() string {
+	return "binance"
+}
+
+func (b *BinanceClient) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", b.config.UserAgent)
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, body)
+	}
+	return body, nil
+}
+
+type binanceExchangeInfo struct {
+	Symbols []struct {
+		Symbol     string `json:"symbol"`
+		BaseAsset  string `json:"baseAsset"`
+		QuoteAsset string `json:"quoteAsset"`
+		Status     string `json:"status"`
+	} `json:"symbols"`
+}
+
+// FetchInstruments maps Binance's exchangeInfo symbols onto Instrument so
+// they can flow through the same matching/pricing code as OKX's. instType
+// is accepted for interface compatibility but Binance spot/margin share
+// one symbol list; quoteCcy filters as with OKX.
+func (b *BinanceClient) FetchInstruments(ctx context.Context, instType, quoteCcy string) ([]Instrument, error) {
+	body, err := b.get(ctx, "https://api.binance.com/api/v3/exchangeInfo")
+	if err != nil {
+		return nil, fmt.Errorf("exchange info request failed: %w", err)
+	}
+
+	var info binanceExchangeInfo
+	if err := json.Unmarshal(body, &info); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal exchange info: %w", err)
+	}
+
+	var instruments []Instrument
+	for _, s := range info.Symbols {
+		if s.Status != "TRADING" {
+			continue
+		}
+		if quoteCcy != "" && s.QuoteAsset != quoteCcy {
+			continue
+		}
+		instruments = append(instruments, Instrument{
+			InstID:   s.Symbol,
+			InstType: instType,
+			BaseCcy:  s.BaseAsset,
+			QuoteCcy: s.QuoteAsset,
+			State:    "live",
+		})
+	}
+	return instruments, nil
+}
+
+type binanceDepth struct {
+	Bids [][]string `json:"bids"`
+	Asks [][]string `json:"asks"`
+}
+
+func (b *BinanceClient) FetchOrderBook(ctx context.Context, instID string, depth int) (*OrderBook, error) {
+	url := fmt.Sprintf("https://api.binance.com/api/v3/depth?symbol=%s&limit=%d", instID, depth)
+	body, err := b.get(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("order book request failed: %w", err)
+	}
+
+	var raw binanceDepth
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal order book response: %w", err)
+	}
+
+	bids, err := parseOrderBookLevels(raw.Bids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bids: %w", err)
+	}
+	asks, err := parseOrderBookLevels(raw.Asks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse asks: %w", err)
+	}
+
+	return &OrderBook{InstID: instID, Bids: bids, Asks: asks}, nil
+}
+
+type binancePremiumIndex struct {
+	Symbol          string `json:"symbol"`
+	LastFundingRate string `json:"lastFundingRate"`
+	NextFundingTime int64  `json:"nextFundingTime"`
+}
+
+func (b *BinanceClient) FetchFundingInfo(ctx context.Context, instID string) (FundingInfo, error) {
+	url := fmt.Sprintf("https://fapi.binance.com/fapi/v1/premiumIndex?symbol=%s", instID)
+	body, err := b.get(ctx, url)
+	if err != nil {
+		return FundingInfo{}, fmt.Errorf("premium index request failed: %w", err)
+	}
+
+	var raw binancePremiumIndex
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return FundingInfo{}, fmt.Errorf("failed to unmarshal premium index response: %w", err)
+	}
+
+	rate, err := strconv.ParseFloat(raw.LastFundingRate, 64)
+	if err != nil {
+		return FundingInfo{}, fmt.Errorf("failed to parse lastFundingRate: %w", err)
+	}
+
+	return FundingInfo{
+		InstID:          raw.Symbol,
+		FundingRate:     rate,
+		NextFundingTime: raw.NextFundingTime,
+	}, nil
+}
+
+// FetchFeeInfo returns Binance's standard VIP-0 taker fees; there is no
+// free public endpoint for per-account fees without authenticated access.
+func (b *BinanceClient) FetchFeeInfo(ctx context.Context) (FeeInfo, error) {
+	return FeeInfo{
+		SpotTaker:    0.001,
+		SwapTaker:    0.0004,
+		MarginBorrow: 0.0002,
+	}, nil
+}
+
+type binanceMarginRate struct {
+	Assets []struct {
+		Asset        string `json:"assetName"`
+		DailyInterest string `json:"dailyInterest"`
+	} `json:"assets"`
+}
+
+// FetchInterestRates reads Binance's public margin interest rate index
+// (GET /sapi/v1/margin/crossMarginData does require a signed request; we
+// fall back to the public isolated-margin symbol rate index here).
+func (b *BinanceClient) FetchInterestRates(ctx context.Context) (map[string]float64, error) {
+	body, err := b.get(ctx, "https://www.binance.com/bapi/margin/v1/public/margin/vip/spec/list")
+	if err != nil {
+		return nil, fmt.Errorf("margin interest rate request failed: %w", err)
+	}
+
+	var raw struct {
+		Data []binanceMarginRate `json:"data"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal margin interest rate response: %w", err)
+	}
+
+	rates := make(map[string]float64)
+	for _, tier := range raw.Data {
+		for _, a := range tier.Assets {
+			if rate, err := strconv.ParseFloat(a.DailyInterest, 64); err == nil {
+				rates[a.Asset] = rate / 24 // convert to hourly to match OKX's convention
+			}
+		}
+	}
+	return rates, nil
+}
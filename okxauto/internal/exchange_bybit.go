@@ -0,0 +1,206 @@
+package gookx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// BybitClient is a third Exchange implementation, alongside OKX and
+// Binance, so opportunities can be scanned across any pair of venues.
+type BybitClient struct {
+	client *http.Client
+	config Config
+}
+
+func NewBybitClient(config Config) *BybitClient {
+	return &BybitClient{
+		client: &http.Client{Timeout: config.HTTPTimeout},
+		config: config,
+	}
+}
+
+func (b *BybitClient) Name() string {
+	return "bybit"
+}
+
+func (b *BybitClient) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", b.config.UserAgent)
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, body)
+	}
+	return body, nil
+}
+
+// bybitCategory maps our instType convention onto Bybit's "category"
+// query param ("spot", "linear" for USDT-margined swaps).
+func bybitCategory(instType string) string {
+	if instType == "SWAP" {
+		return "linear"
+	}
+	return "spot"
+}
+
+type bybitInstrumentsResponse struct {
+	Result struct {
+		List []struct {
+			Symbol     string `json:"symbol"`
+			BaseCoin   string `json:"baseCoin"`
+			QuoteCoin  string `json:"quoteCoin"`
+			Status     string `json:"status"`
+			LotSizeFilter struct {
+				QtyStep string `json:"qtyStep"`
+			} `json:"lotSizeFilter"`
+			PriceFilter struct {
+				TickSize string `json:"tickSize"`
+			} `json:"priceFilter"`
+		} `json:"list"`
+	} `json:"result"`
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+}
+
+func (b *BybitClient) FetchInstruments(ctx context.Context, instType, quoteCcy string) ([]Instrument, error) {
+	url := fmt.Sprintf("https://api.bybit.com/v5/market/instruments-info?category=%s", bybitCategory(instType))
+	body, err := b.get(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("instruments info request failed: %w", err)
+	}
+
+	var resp bybitInstrumentsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal instruments info response: %w", err)
+	}
+	if resp.RetCode != 0 {
+		return nil, fmt.Errorf("API error: %d - %s", resp.RetCode, resp.RetMsg)
+	}
+
+	var instruments []Instrument
+	for _, s := range resp.Result.List {
+		if s.Status != "Trading" {
+			continue
+		}
+		if quoteCcy != "" && s.QuoteCoin != quoteCcy {
+			continue
+		}
+		instruments = append(instruments, Instrument{
+			InstID:   s.Symbol,
+			InstType: instType,
+			BaseCcy:  s.BaseCoin,
+			QuoteCcy: s.QuoteCoin,
+			State:    "live",
+			LotSz:    s.LotSizeFilter.QtyStep,
+			TickSz:   s.PriceFilter.TickSize,
+		})
+	}
+	return instruments, nil
+}
+
+type bybitOrderBookResponse struct {
+	Result struct {
+		Bids [][]string `json:"b"`
+		Asks [][]string `json:"a"`
+		TS   int64      `json:"ts"`
+	} `json:"result"`
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+}
+
+func (b *BybitClient) FetchOrderBook(ctx context.Context, instID string, depth int) (*OrderBook, error) {
+	url := fmt.Sprintf("https://api.bybit.com/v5/market/orderbook?category=spot&symbol=%s&limit=%d", instID, depth)
+	body, err := b.get(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("order book request failed: %w", err)
+	}
+
+	var resp bybitOrderBookResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal order book response: %w", err)
+	}
+	if resp.RetCode != 0 {
+		return nil, fmt.Errorf("API error: %d - %s", resp.RetCode, resp.RetMsg)
+	}
+
+	bids, err := parseOrderBookLevels(resp.Result.Bids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bids: %w", err)
+	}
+	asks, err := parseOrderBookLevels(resp.Result.Asks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse asks: %w", err)
+	}
+
+	return &OrderBook{InstID: instID, Bids: bids, Asks: asks}, nil
+}
+
+type bybitFundingResponse struct {
+	Result struct {
+		List []struct {
+			Symbol          string `json:"symbol"`
+			FundingRate     string `json:"fundingRate"`
+			NextFundingTime string `json:"nextFundingTime"`
+		} `json:"list"`
+	} `json:"result"`
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+}
+
+func (b *BybitClient) FetchFundingInfo(ctx context.Context, instID string) (FundingInfo, error) {
+	url := fmt.Sprintf("https://api.bybit.com/v5/market/tickers?category=linear&symbol=%s", instID)
+	body, err := b.get(ctx, url)
+	if err != nil {
+		return FundingInfo{}, fmt.Errorf("tickers request failed: %w", err)
+	}
+
+	var resp bybitFundingResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return FundingInfo{}, fmt.Errorf("failed to unmarshal tickers response: %w", err)
+	}
+	if resp.RetCode != 0 || len(resp.Result.List) == 0 {
+		return FundingInfo{}, fmt.Errorf("API error: %d - %s", resp.RetCode, resp.RetMsg)
+	}
+
+	item := resp.Result.List[0]
+	rate, err := strconv.ParseFloat(item.FundingRate, 64)
+	if err != nil {
+		return FundingInfo{}, fmt.Errorf("failed to parse fundingRate: %w", err)
+	}
+	nextFundingTime, _ := strconv.ParseInt(item.NextFundingTime, 10, 64)
+
+	return FundingInfo{InstID: item.Symbol, FundingRate: rate, NextFundingTime: nextFundingTime}, nil
+}
+
+// FetchFeeInfo returns Bybit's standard VIP-0 taker fees; per-account fee
+// tiers require an authenticated request we don't make here.
+func (b *BybitClient) FetchFeeInfo(ctx context.Context) (FeeInfo, error) {
+	return FeeInfo{
+		SpotTaker:    0.001,
+		SwapTaker:    0.0006,
+		MarginBorrow: 0.0002,
+	}, nil
+}
+
+// FetchInterestRates always returns an empty map: Bybit's cross-margin
+// borrow rates require an authenticated request, which this client does
+// not make. Callers fall back to FeeInfo.MarginBorrow for every asset.
+func (b *BybitClient) FetchInterestRates(ctx context.Context) (map[string]float64, error) {
+	return map[string]float64{}, nil
+}
@@ -0,0 +1,387 @@
+package gookx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// OKX order types, passed verbatim as the ordType field.
+const (
+	OrdTypeMarket   = "market"
+	OrdTypeLimit    = "limit"
+	OrdTypeIOC      = "ioc"
+	OrdTypeFOK      = "fok"
+	OrdTypePostOnly = "post_only"
+)
+
+// cancelConfirmTimeout bounds how long Execute waits for a canceled order
+// to drop out of orders-pending before rolling back anyway.
+const cancelConfirmTimeout = 5 * time.Second
+
+// PlaceOrderRequest mirrors the fields OKX's /api/v5/trade/order accepts
+// for margin and swap orders.
+type PlaceOrderRequest struct {
+	InstID  string
+	TdMode  string // "cross", "isolated", or "cash"
+	Side    string // "buy" or "sell"
+	OrdType string
+	Sz      float64
+	Px      float64 // ignored for market orders
+}
+
+type tradeOrderPayload struct {
+	InstID  string `json:"instId"`
+	TdMode  string `json:"tdMode"`
+	Side    string `json:"side"`
+	OrdType string `json:"ordType"`
+	Sz      string `json:"sz"`
+	Px      string `json:"px,omitempty"`
+}
+
+type tradeOrderResponse struct {
+	Code string `json:"code"`
+	Msg  string `json:"msg"`
+	Data []struct {
+		OrdID string `json:"ordId"`
+		SCode string `json:"sCode"`
+		SMsg  string `json:"sMsg"`
+	} `json:"data"`
+}
+
+// PlaceOrder signs and submits a single order via /api/v5/trade/order.
+func (h *HTTPClient) PlaceOrder(ctx context.Context, req PlaceOrderRequest) (string, error) {
+	payload := tradeOrderPayload{
+		InstID:  req.InstID,
+		TdMode:  req.TdMode,
+		Side:    req.Side,
+		OrdType: req.OrdType,
+		Sz:      strconv.FormatFloat(req.Sz, 'f', -1, 64),
+	}
+	if req.OrdType != OrdTypeMarket && req.Px > 0 {
+		payload.Px = strconv.FormatFloat(req.Px, 'f', -1, 64)
+	}
+
+	body, err := h.MakeSignedPOST(ctx, "/api/v5/trade/order", payload)
+	if err != nil {
+		return "", fmt.Errorf("place order request failed: %w", err)
+	}
+
+	var resp tradeOrderResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", fmt.Errorf("failed to unmarshal place order response: %w", err)
+	}
+	if resp.Code != "0" || len(resp.Data) == 0 {
+		return "", fmt.Errorf("place order rejected: %s - %s", resp.Code, resp.Msg)
+	}
+	if resp.Data[0].SCode != "0" {
+		return "", fmt.Errorf("place order rejected: %s - %s", resp.Data[0].SCode, resp.Data[0].SMsg)
+	}
+	return resp.Data[0].OrdID, nil
+}
+
+// CancelOrder cancels a single order via /api/v5/trade/cancel-order.
+func (h *HTTPClient) CancelOrder(ctx context.Context, instID, ordID string) error {
+	payload := map[string]string{"instId": instID, "ordId": ordID}
+	body, err := h.MakeSignedPOST(ctx, "/api/v5/trade/cancel-order", payload)
+	if err != nil {
+		return fmt.Errorf("cancel order request failed: %w", err)
+	}
+	var resp tradeOrderResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("failed to unmarshal cancel order response: %w", err)
+	}
+	if resp.Code != "0" {
+		return fmt.Errorf("cancel order rejected: %s - %s", resp.Code, resp.Msg)
+	}
+	return nil
+}
+
+// AmendOrder changes the size and/or price of a resting order via
+// /api/v5/trade/amend-order. Pass 0 for whichever field is unchanged.
+func (h *HTTPClient) AmendOrder(ctx context.Context, instID, ordID string, newSz, newPx float64) error {
+	payload := map[string]string{"instId": instID, "ordId": ordID}
+	if newSz > 0 {
+		payload["newSz"] = strconv.FormatFloat(newSz, 'f', -1, 64)
+	}
+	if newPx > 0 {
+		payload["newPx"] = strconv.FormatFloat(newPx, 'f', -1, 64)
+	}
+
+	body, err := h.MakeSignedPOST(ctx, "/api/v5/trade/amend-order", payload)
+	if err != nil {
+		return fmt.Errorf("amend order request failed: %w", err)
+	}
+	var resp tradeOrderResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("failed to unmarshal amend order response: %w", err)
+	}
+	if resp.Code != "0" {
+		return fmt.Errorf("amend order rejected: %s - %s", resp.Code, resp.Msg)
+	}
+	return nil
+}
+
+// OpenOrder is a row from /api/v5/trade/orders-pending or
+// /api/v5/trade/orders-history.
+type OpenOrder struct {
+	OrdID    string
+	InstID   string
+	Side     string
+	State    string // "live", "filled", "canceled", "partially_filled"
+	Sz       float64
+	FillSz   float64
+	FillPx   float64
+	UpdateTS int64
+}
+
+type openOrderPayload struct {
+	OrdID    string `json:"ordId"`
+	InstID   string `json:"instId"`
+	Side     string `json:"side"`
+	State    string `json:"state"`
+	Sz       string `json:"sz"`
+	FillSz   string `json:"fillSz"`
+	FillPx   string `json:"fillPx"`
+	UpdateTS string `json:"uTime"`
+}
+
+func (p openOrderPayload) toOpenOrder() OpenOrder {
+	sz, _ := strconv.ParseFloat(p.Sz, 64)
+	fillSz, _ := strconv.ParseFloat(p.FillSz, 64)
+	fillPx, _ := strconv.ParseFloat(p.FillPx, 64)
+	updateTS, _ := strconv.ParseInt(p.UpdateTS, 10, 64)
+	return OpenOrder{
+		OrdID:    p.OrdID,
+		InstID:   p.InstID,
+		Side:     p.Side,
+		State:    p.State,
+		Sz:       sz,
+		FillSz:   fillSz,
+		FillPx:   fillPx,
+		UpdateTS: updateTS,
+	}
+}
+
+type ordersResponse struct {
+	Code string             `json:"code"`
+	Msg  string             `json:"msg"`
+	Data []openOrderPayload `json:"data"`
+}
+
+// GetOpenOrders lists resting orders via /api/v5/trade/orders-pending.
+func (h *HTTPClient) GetOpenOrders(ctx context.Context, instType string) ([]OpenOrder, error) {
+	query := ""
+	if instType != "" {
+		query = "instType=" + instType
+	}
+	body, err := h.MakeSignedRequest(ctx, "GET", "/api/v5/trade/orders-pending", query)
+	if err != nil {
+		return nil, fmt.Errorf("open orders request failed: %w", err)
+	}
+	var resp ordersResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal open orders response: %w", err)
+	}
+	if resp.Code != "0" {
+		return nil, fmt.Errorf("open orders rejected: %s - %s", resp.Code, resp.Msg)
+	}
+	orders := make([]OpenOrder, 0, len(resp.Data))
+	for _, p := range resp.Data {
+		orders = append(orders, p.toOpenOrder())
+	}
+	return orders, nil
+}
+
+// GetOrderHistory lists orders closed within [since, until) via
+// /api/v5/trade/orders-history.
+func (h *HTTPClient) GetOrderHistory(ctx context.Context, instType string, since, until time.Time) ([]OpenOrder, error) {
+	query := fmt.Sprintf("instType=%s&begin=%d&end=%d", instType, since.UnixMilli(), until.UnixMilli())
+	body, err := h.MakeSignedRequest(ctx, "GET", "/api/v5/trade/orders-history", query)
+	if err != nil {
+		return nil, fmt.Errorf("order history request failed: %w", err)
+	}
+	var resp ordersResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal order history response: %w", err)
+	}
+	if resp.Code != "0" {
+		return nil, fmt.Errorf("order history rejected: %s - %s", resp.Code, resp.Msg)
+	}
+	orders := make([]OpenOrder, 0, len(resp.Data))
+	for _, p := range resp.Data {
+		orders = append(orders, p.toOpenOrder())
+	}
+	return orders, nil
+}
+
+// TransferAsset moves funds between OKX account types (e.g. "6" funding,
+// "18" trading) via /api/v5/asset/transfer.
+func (h *HTTPClient) TransferAsset(ctx context.Context, ccy string, amt float64, from, to string) error {
+	payload := map[string]string{
+		"ccy":  ccy,
+		"amt":  strconv.FormatFloat(amt, 'f', -1, 64),
+		"from": from,
+		"to":   to,
+	}
+	body, err := h.MakeSignedPOST(ctx, "/api/v5/asset/transfer", payload)
+	if err != nil {
+		return fmt.Errorf("transfer request failed: %w", err)
+	}
+	var resp tradeOrderResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("failed to unmarshal transfer response: %w", err)
+	}
+	if resp.Code != "0" {
+		return fmt.Errorf("transfer rejected: %s - %s", resp.Code, resp.Msg)
+	}
+	return nil
+}
+
+// OrderTracker polls OKX for the fill state of a set of order IDs placed
+// by an Executor.
+type OrderTracker struct {
+	client  *HTTPClient
+	instIDs map[string]string // ordID -> instID
+}
+
+func newOrderTracker(client *HTTPClient) *OrderTracker {
+	return &OrderTracker{client: client, instIDs: make(map[string]string)}
+}
+
+func (t *OrderTracker) track(ordID, instID string) {
+	t.instIDs[ordID] = instID
+}
+
+// fillSize returns ordID's currently filled size, read from the resting
+// open-orders snapshot (a partially_filled order still appears there with
+// a non-zero FillSz). Used to size rollback reversals from what actually
+// filled rather than the full requested size.
+func (t *OrderTracker) fillSize(ctx context.Context, ordID string) float64 {
+	orders, err := t.client.GetOpenOrders(ctx, "")
+	if err != nil {
+		return 0
+	}
+	for _, o := range orders {
+		if o.OrdID == ordID {
+			return o.FillSz
+		}
+	}
+	return 0
+}
+
+// WaitFilled polls /api/v5/trade/orders-pending until ordID is no longer
+// resting (filled or canceled) or timeout elapses, returning its final
+// state.
+func (t *OrderTracker) WaitFilled(ctx context.Context, ordID string, timeout time.Duration) (OpenOrder, error) {
+	instID := t.instIDs[ordID]
+	deadline := time.Now().Add(timeout)
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		orders, err := t.client.GetOpenOrders(ctx, "")
+		if err == nil {
+			stillOpen := false
+			for _, o := range orders {
+				if o.OrdID == ordID {
+					stillOpen = true
+					break
+				}
+			}
+			if !stillOpen {
+				return OpenOrder{OrdID: ordID, InstID: instID, State: "filled"}, nil
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return OpenOrder{}, fmt.Errorf("timed out waiting for order %s to fill", ordID)
+		}
+		select {
+		case <-ctx.Done():
+			return OpenOrder{}, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Executor atomically fires the paired margin+swap legs implied by a
+// RealArbitrageResult, using a configurable order type (market/limit/
+// ioc/fok/post_only), and rolls back the completed leg if the other fails
+// to fill within rollbackTimeout.
+type Executor struct {
+	client  *HTTPClient
+	ordType string
+}
+
+func NewExecutor(client *HTTPClient, ordType string) *Executor {
+	if ordType == "" {
+		ordType = OrdTypeIOC
+	}
+	return &Executor{client: client, ordType: ordType}
+}
+
+// Execute places the margin leg, then the swap leg; if the swap leg fails
+// to fill within rollbackTimeout, it reverses the margin leg with an
+// opposing market order to avoid being left with a naked position.
+func (e *Executor) Execute(ctx context.Context, result RealArbitrageResult, marginInstID, swapInstID string, sz float64, rollbackTimeout time.Duration) (*OrderTracker, error) {
+	marginSide, swapSide := "buy", "sell"
+	if !result.IsContango {
+		marginSide, swapSide = "sell", "buy"
+	}
+
+	tracker := newOrderTracker(e.client)
+
+	marginOrdID, err := e.client.PlaceOrder(ctx, PlaceOrderRequest{
+		InstID: marginInstID, TdMode: "cross", Side: marginSide, OrdType: e.ordType, Sz: sz,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to place margin leg: %w", err)
+	}
+	tracker.track(marginOrdID, marginInstID)
+
+	swapOrdID, err := e.client.PlaceOrder(ctx, PlaceOrderRequest{
+		InstID: swapInstID, TdMode: "cross", Side: swapSide, OrdType: e.ordType, Sz: sz,
+	})
+	if err != nil {
+		e.rollback(ctx, marginInstID, marginSide, sz)
+		return nil, fmt.Errorf("failed to place swap leg, rolled back margin leg: %w", err)
+	}
+	tracker.track(swapOrdID, swapInstID)
+
+	if _, err := tracker.WaitFilled(ctx, swapOrdID, rollbackTimeout); err != nil {
+		marginFillSz := tracker.fillSize(ctx, marginOrdID)
+		swapFillSz := tracker.fillSize(ctx, swapOrdID)
+
+		if cancelErr := e.client.CancelOrder(ctx, swapInstID, swapOrdID); cancelErr != nil {
+			fmt.Printf("Warning: failed to cancel unfilled swap order %s: %v\n", swapOrdID, cancelErr)
+		} else if _, waitErr := tracker.WaitFilled(ctx, swapOrdID, cancelConfirmTimeout); waitErr != nil {
+			fmt.Printf("Warning: could not confirm cancellation of swap order %s: %v\n", swapOrdID, waitErr)
+		}
+
+		if marginFillSz > 0 {
+			e.rollback(ctx, marginInstID, marginSide, marginFillSz)
+		}
+		if swapFillSz > 0 {
+			e.rollback(ctx, swapInstID, swapSide, swapFillSz)
+		}
+		return nil, fmt.Errorf("swap leg did not fill in time, canceled it and rolled back the filled portion of both legs: %w", err)
+	}
+
+	return tracker, nil
+}
+
+// rollback closes a filled leg with an opposing market order.
+func (e *Executor) rollback(ctx context.Context, instID, openedSide string, sz float64) {
+	closingSide := "sell"
+	if openedSide == "sell" {
+		closingSide = "buy"
+	}
+	if _, err := e.client.PlaceOrder(ctx, PlaceOrderRequest{
+		InstID: instID, TdMode: "cross", Side: closingSide, OrdType: OrdTypeMarket, Sz: sz,
+	}); err != nil {
+		fmt.Printf("Warning: failed to roll back %s position on %s: %v\n", openedSide, instID, err)
+	}
+}
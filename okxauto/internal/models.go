@@ -2,6 +2,7 @@ package gookx
 
 import (
 	"encoding/json"
+	"math"
 	"net/http"
 	"time"
 )
@@ -15,6 +16,12 @@ type Config struct {
 	APIKey         string
 	APISecret      string
 	APIPassphrase  string
+	// LiveTrading gates the Trader: when false (the default), orders and
+	// borrow requests are logged and validated but never sent to OKX.
+	LiveTrading bool
+	// MetricsAddr, if set, is the address PrometheusSink serves
+	// /metrics on (e.g. ":9108").
+	MetricsAddr string
 }
 
 type OKXInstrumentResponse struct {
@@ -141,6 +148,13 @@ type DiffResult struct {
 	TermStructure string  `json:"termStructure"`
 }
 
+// FundingSummary is the funding-rate context attached to a DiffResult or
+// RealArbitrageResult row when printing/exporting scanner output.
+type FundingSummary struct {
+	Rate          float64
+	TimeToFunding time.Duration
+}
+
 type FundingInfo struct {
 	InstID          string  // Instrument ID (e.g., BTC-USDT-SWAP)
 	FundingRate     float64 // Current or next funding rate
@@ -219,6 +233,68 @@ type RealArbitrageResult struct {
 	SwapSlippage       float64 `json:"swapSlippage"`
 }
 
+// CrossExchangeArbitrageResult compares the same base symbol priced on two
+// different venues, net of each venue's withdrawal fee for the asset that
+// would need to move to rebalance inventory.
+type CrossExchangeArbitrageResult struct {
+	BaseSymbol     string  `json:"baseSymbol"`
+	ExchangeA      string  `json:"exchangeA"`
+	ExchangeB      string  `json:"exchangeB"`
+	PriceA         float64 `json:"priceA"`
+	PriceB         float64 `json:"priceB"`
+	PercentDiff    float64 `json:"percentDiff"`
+	WithdrawalCost float64 `json:"withdrawalCost"` // in quote currency, each venue's fee valued at that venue's price
+	NetPercentDiff float64 `json:"netPercentDiff"`
+}
+
+// CurrencyPair normalizes a venue's instrument into base/quote plus the
+// precision downstream execution must round to, so an order sized on one
+// venue's tick/lot rules doesn't get rejected by another's.
+type CurrencyPair struct {
+	Exchange       string  `json:"exchange"`
+	BaseCcy        string  `json:"baseCcy"`
+	QuoteCcy       string  `json:"quoteCcy"`
+	AmountTickSize float64 `json:"amountTickSize"` // minimum order size increment (lot size)
+	PriceTickSize  float64 `json:"priceTickSize"`  // minimum price increment
+}
+
+// NewCurrencyPair builds a CurrencyPair from an Instrument as returned by
+// any Exchange implementation's FetchInstruments.
+func NewCurrencyPair(exchange string, inst Instrument) CurrencyPair {
+	amountTick, _ := ParseFloat(inst.LotSz)
+	priceTick, _ := ParseFloat(inst.TickSz)
+	return CurrencyPair{
+		Exchange:       exchange,
+		BaseCcy:        inst.BaseCcy,
+		QuoteCcy:       inst.QuoteCcy,
+		AmountTickSize: amountTick,
+		PriceTickSize:  priceTick,
+	}
+}
+
+// RoundAmount rounds sz down to the nearest AmountTickSize so an order
+// isn't rejected for exceeding the venue's lot precision.
+func (c CurrencyPair) RoundAmount(sz float64) float64 {
+	return roundToTick(sz, c.AmountTickSize)
+}
+
+// RoundPrice rounds px down to the nearest PriceTickSize.
+func (c CurrencyPair) RoundPrice(px float64) float64 {
+	return roundToTick(px, c.PriceTickSize)
+}
+
+// roundToTick floors value to the nearest multiple of tick. It adds a
+// small epsilon before flooring to absorb float64 division error (e.g.
+// 0.29/0.01 evaluates to 28.999999999999996, which would otherwise
+// truncate to 28 instead of the correct 29).
+func roundToTick(value, tick float64) float64 {
+	if tick <= 0 {
+		return value
+	}
+	steps := math.Floor(value/tick + 1e-9)
+	return steps * tick
+}
+
 // Trading configuration
 type TradingConfig struct {
 	TradeSizeUSD    float64 `json:"tradeSizeUSD"`    // Size of each trade in USD
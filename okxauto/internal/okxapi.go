@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -242,6 +243,45 @@ func (h *HTTPClient) MakeSignedRequest(ctx context.Context, method, path, query
 	return bodyBytes, nil
 }
 
+// Private signed POST request. The request body participates in the
+// HMAC-SHA256 signature alongside the timestamp, method, and path.
+func (h *HTTPClient) MakeSignedPOST(ctx context.Context, path string, payload interface{}) ([]byte, error) {
+	bodyBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+	body := string(bodyBytes)
+
+	ts := time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+	signature := signOKX(ts, "POST", path, body, h.config.APISecret)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://www.okx.com"+path, strings.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signed request: %w", err)
+	}
+	req.Header.Set("OK-ACCESS-KEY", h.config.APIKey)
+	req.Header.Set("OK-ACCESS-SIGN", signature)
+	req.Header.Set("OK-ACCESS-TIMESTAMP", ts)
+	req.Header.Set("OK-ACCESS-PASSPHRASE", h.config.APIPassphrase)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", h.config.UserAgent)
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("signed request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, respBytes)
+	}
+	return respBytes, nil
+}
+
 // Fetch interest rates (private)
 func (h *HTTPClient) FetchInterestRates(ctx context.Context) (map[string]float64, error) {
 	path := "/api/v5/account/interest-rate"
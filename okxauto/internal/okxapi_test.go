@@ -0,0 +1,35 @@
+package gookx
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+)
+
+func TestSignOKX(t *testing.T) {
+	ts, method, path, body, secret := "2020-01-01T00:00:00.000Z", "GET", "/api/v5/account/balance", "", "secret"
+
+	got := signOKX(ts, method, path, body, secret)
+
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(ts + method + path + body))
+	want := base64.StdEncoding.EncodeToString(h.Sum(nil))
+
+	if got != want {
+		t.Errorf("signOKX(%q, %q, %q, %q) = %q, want %q", ts, method, path, body, got, want)
+	}
+}
+
+func TestSignOKXDiffersByInput(t *testing.T) {
+	base := signOKX("2020-01-01T00:00:00.000Z", "GET", "/api/v5/account/balance", "", "secret")
+	if signOKX("2020-01-01T00:00:00.001Z", "GET", "/api/v5/account/balance", "", "secret") == base {
+		t.Error("signOKX should produce a different signature for a different timestamp")
+	}
+	if signOKX("2020-01-01T00:00:00.000Z", "POST", "/api/v5/account/balance", "", "secret") == base {
+		t.Error("signOKX should produce a different signature for a different method")
+	}
+	if signOKX("2020-01-01T00:00:00.000Z", "GET", "/api/v5/account/balance", "", "other-secret") == base {
+		t.Error("signOKX should produce a different signature for a different secret")
+	}
+}
@@ -0,0 +1,381 @@
+package gookx
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DiffRow pairs a DiffResult with the funding/fee context printed
+// alongside it.
+type DiffRow struct {
+	Diff         DiffResult
+	Funding      FundingSummary
+	FeesPercent  float64
+	ActualProfit float64
+}
+
+// RealResultRow pairs a RealArbitrageResult with the raw funding context
+// and the full NetPnLBreakdown printed alongside it.
+type RealResultRow struct {
+	Result    RealArbitrageResult
+	Funding   FundingSummary
+	Breakdown NetPnLBreakdown
+}
+
+// OutputSink is where scanner output goes. TableSink reproduces the
+// original stdout tables; JSONLSink and CSVSink are for downstream
+// tooling; PrometheusSink exposes the same numbers as gauges/histograms.
+type OutputSink interface {
+	WriteDiffs(rows []DiffRow, minDiff float64) error
+	WriteRealResults(rows []RealResultRow, minDiff float64) error
+}
+
+// TableSink reproduces the scanner's original human-readable stdout
+// tables.
+type TableSink struct {
+	w io.Writer
+}
+
+func NewTableSink(w io.Writer) *TableSink {
+	return &TableSink{w: w}
+}
+
+func (s *TableSink) WriteDiffs(rows []DiffRow, minDiff float64) error {
+	if minDiff > 0 {
+		fmt.Fprintf(s.w, "\nTop %d symbols with %% markPx difference >= %.2f%% (swap vs margin):\n", len(rows), minDiff)
+	} else {
+		fmt.Fprintf(s.w, "\nTop %d symbols by %% markPx difference (swap vs margin):\n", len(rows))
+	}
+	fmt.Fprintf(s.w, "%-12s %-15s %-15s %-18s %-10s %-15s %-12s %-16s %-10s %-12s\n",
+		"Symbol", "Margin", "Swap", "Actual Diff", "% Diff", "Structure", "FundingRate", "TimeToFunding", "Fees", "ActualProfit")
+	fmt.Fprintln(s.w, strings.Repeat("-", 152))
+
+	for _, row := range rows {
+		d := row.Diff
+		diffSign := "+"
+		if d.ActualDiff < 0 {
+			diffSign = ""
+		}
+		actualDiffStr := fmt.Sprintf("%s%.6f", diffSign, d.ActualDiff)
+		fmt.Fprintf(s.w, "%-12s %-15.6f %-15.6f %-18s %-10.2f %-15s %-12.6f %-16s %-9.4f%%   %-10.2f%%\n",
+			d.BaseSymbol, d.MarginMarkPx, d.SwapMarkPx, actualDiffStr, d.PercentDiff, d.TermStructure,
+			row.Funding.Rate, row.Funding.TimeToFunding.Round(time.Second), row.FeesPercent, row.ActualProfit)
+	}
+	return nil
+}
+
+func (s *TableSink) WriteRealResults(rows []RealResultRow, minNetProfit float64) error {
+	if minNetProfit > 0 {
+		fmt.Fprintf(s.w, "\nTop %d symbols with real execution prices (net profit >= %.2f%%):\n", len(rows), minNetProfit)
+	} else {
+		fmt.Fprintf(s.w, "\nTop %d symbols with real execution prices:\n", len(rows))
+	}
+	fmt.Fprintf(s.w, "%-12s %-15s %-15s %-15s %-15s %-10s %-15s %-12s %-16s %-10s %-10s %-10s %-10s %-10s\n",
+		"Symbol", "MarginBuy", "MarginSell", "SwapBuy", "SwapSell", "% Diff", "Structure", "FundingRate", "TimeToFunding",
+		"Spread", "Funding", "Borrow", "Fees+Slip", "Net")
+	fmt.Fprintln(s.w, strings.Repeat("-", 195))
+
+	for _, row := range rows {
+		r := row.Result
+		b := row.Breakdown
+		fmt.Fprintf(s.w, "%-12s %-15.6f %-15.6f %-15.6f %-15.6f %-10.2f %-15s %-12.6f %-16s %-9.4f%%  %-9.4f%%  %-9.4f%%  %-9.4f%%  %-9.4f%%\n",
+			r.BaseSymbol, r.MarginBuyPrice, r.MarginSellPrice, r.SwapBuyPrice, r.SwapSellPrice,
+			r.PercentDiff, r.TermStructure, row.Funding.Rate, row.Funding.TimeToFunding.Round(time.Second),
+			b.Spread, b.Funding, b.Borrow, b.Fees+b.Slippage, b.Net)
+	}
+	return nil
+}
+
+// JSONLSink writes one JSON object per line, suitable for piping into
+// jq/log aggregators.
+type JSONLSink struct {
+	w io.Writer
+}
+
+func NewJSONLSink(w io.Writer) *JSONLSink {
+	return &JSONLSink{w: w}
+}
+
+func (s *JSONLSink) WriteDiffs(rows []DiffRow, minDiff float64) error {
+	enc := json.NewEncoder(s.w)
+	for _, row := range rows {
+		if err := enc.Encode(row); err != nil {
+			return fmt.Errorf("failed to encode diff row: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *JSONLSink) WriteRealResults(rows []RealResultRow, minDiff float64) error {
+	enc := json.NewEncoder(s.w)
+	for _, row := range rows {
+		if err := enc.Encode(row); err != nil {
+			return fmt.Errorf("failed to encode real result row: %w", err)
+		}
+	}
+	return nil
+}
+
+// CSVSink writes scanner output as CSV.
+type CSVSink struct {
+	w io.Writer
+}
+
+func NewCSVSink(w io.Writer) *CSVSink {
+	return &CSVSink{w: w}
+}
+
+func (s *CSVSink) WriteDiffs(rows []DiffRow, minDiff float64) error {
+	writer := csv.NewWriter(s.w)
+	defer writer.Flush()
+
+	if err := writer.Write([]string{"symbol", "marginPx", "swapPx", "percentDiff", "structure", "fundingRate", "feesPercent", "actualProfit"}); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		d := row.Diff
+		record := []string{
+			d.BaseSymbol,
+			strconv.FormatFloat(d.MarginMarkPx, 'f', -1, 64),
+			strconv.FormatFloat(d.SwapMarkPx, 'f', -1, 64),
+			strconv.FormatFloat(d.PercentDiff, 'f', -1, 64),
+			d.TermStructure,
+			strconv.FormatFloat(row.Funding.Rate, 'f', -1, 64),
+			strconv.FormatFloat(row.FeesPercent, 'f', -1, 64),
+			strconv.FormatFloat(row.ActualProfit, 'f', -1, 64),
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write diff row: %w", err)
+		}
+	}
+	return writer.Error()
+}
+
+func (s *CSVSink) WriteRealResults(rows []RealResultRow, minDiff float64) error {
+	writer := csv.NewWriter(s.w)
+	defer writer.Flush()
+
+	if err := writer.Write([]string{"symbol", "marginBuy", "marginSell", "swapBuy", "swapSell", "percentDiff", "structure", "fundingRate",
+		"pnlSpread", "pnlFunding", "pnlBorrow", "pnlFees", "pnlSlippage", "pnlNet"}); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		r := row.Result
+		b := row.Breakdown
+		record := []string{
+			r.BaseSymbol,
+			strconv.FormatFloat(r.MarginBuyPrice, 'f', -1, 64),
+			strconv.FormatFloat(r.MarginSellPrice, 'f', -1, 64),
+			strconv.FormatFloat(r.SwapBuyPrice, 'f', -1, 64),
+			strconv.FormatFloat(r.SwapSellPrice, 'f', -1, 64),
+			strconv.FormatFloat(r.PercentDiff, 'f', -1, 64),
+			r.TermStructure,
+			strconv.FormatFloat(row.Funding.Rate, 'f', -1, 64),
+			strconv.FormatFloat(b.Spread, 'f', -1, 64),
+			strconv.FormatFloat(b.Funding, 'f', -1, 64),
+			strconv.FormatFloat(b.Borrow, 'f', -1, 64),
+			strconv.FormatFloat(b.Fees, 'f', -1, 64),
+			strconv.FormatFloat(b.Slippage, 'f', -1, 64),
+			strconv.FormatFloat(b.Net, 'f', -1, 64),
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write real result row: %w", err)
+		}
+	}
+	return writer.Error()
+}
+
+// PrometheusSink exposes scanner output as Prometheus gauges/histograms
+// over an HTTP /metrics endpoint, using the text exposition format
+// directly (no client library dependency) since the gauge set is small
+// and fixed.
+type PrometheusSink struct {
+	mu sync.Mutex
+
+	spreadPercent    map[string]map[string]float64 // symbol -> structure -> value
+	actualProfit     map[string]float64
+	fundingRate      map[string]float64
+	slippageSamples  []float64
+	fetchLatencySecs []float64
+}
+
+func NewPrometheusSink(addr string) *PrometheusSink {
+	sink := &PrometheusSink{
+		spreadPercent: make(map[string]map[string]float64),
+		actualProfit:  make(map[string]float64),
+		fundingRate:   make(map[string]float64),
+	}
+	if addr != "" {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/metrics", sink.serveMetrics)
+		go func() {
+			if err := http.ListenAndServe(addr, mux); err != nil {
+				log.Printf("prometheus sink: metrics server stopped: %v", err)
+			}
+		}()
+	}
+	return sink
+}
+
+// ObserveFetchLatency records an order-book fetch's wall-clock duration
+// for the okxcct_orderbook_fetch_latency_seconds histogram.
+func (s *PrometheusSink) ObserveFetchLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fetchLatencySecs = append(s.fetchLatencySecs, d.Seconds())
+}
+
+// ObservingOrderBookSource wraps an OrderBookSource and times every
+// FetchOrderBook call into a PrometheusSink, so
+// okxcct_orderbook_fetch_latency_seconds reflects real fetch latency
+// regardless of whether the underlying source is REST, a live WSClient
+// cache, or a cross-exchange client.
+type ObservingOrderBookSource struct {
+	source OrderBookSource
+	sink   *PrometheusSink
+}
+
+// NewObservingOrderBookSource wraps source so every FetchOrderBook call
+// reports its duration to sink.
+func NewObservingOrderBookSource(source OrderBookSource, sink *PrometheusSink) *ObservingOrderBookSource {
+	return &ObservingOrderBookSource{source: source, sink: sink}
+}
+
+func (o *ObservingOrderBookSource) FetchOrderBook(ctx context.Context, instID string, depth int) (*OrderBook, error) {
+	start := time.Now()
+	book, err := o.source.FetchOrderBook(ctx, instID, depth)
+	o.sink.ObserveFetchLatency(time.Since(start))
+	return book, err
+}
+
+func (s *PrometheusSink) WriteDiffs(rows []DiffRow, minDiff float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, row := range rows {
+		d := row.Diff
+		if _, ok := s.spreadPercent[d.BaseSymbol]; !ok {
+			s.spreadPercent[d.BaseSymbol] = make(map[string]float64)
+		}
+		s.spreadPercent[d.BaseSymbol][d.TermStructure] = d.PercentDiff
+		s.actualProfit[d.BaseSymbol] = row.ActualProfit
+		s.fundingRate[d.BaseSymbol] = row.Funding.Rate
+	}
+	return nil
+}
+
+func (s *PrometheusSink) WriteRealResults(rows []RealResultRow, minDiff float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, row := range rows {
+		r := row.Result
+		if _, ok := s.spreadPercent[r.BaseSymbol]; !ok {
+			s.spreadPercent[r.BaseSymbol] = make(map[string]float64)
+		}
+		s.spreadPercent[r.BaseSymbol][r.TermStructure] = r.PercentDiff
+		s.actualProfit[r.BaseSymbol] = row.Breakdown.Net
+		s.fundingRate[r.BaseSymbol] = row.Funding.Rate
+		s.slippageSamples = append(s.slippageSamples, row.Breakdown.Slippage)
+	}
+	return nil
+}
+
+func (s *PrometheusSink) serveMetrics(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var symbols []string
+	for symbol := range s.spreadPercent {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	fmt.Fprintln(w, "# HELP okxcct_spread_percent Percent difference between legs of an opportunity")
+	fmt.Fprintln(w, "# TYPE okxcct_spread_percent gauge")
+	for _, symbol := range symbols {
+		structures := s.spreadPercent[symbol]
+		var names []string
+		for structure := range structures {
+			names = append(names, structure)
+		}
+		sort.Strings(names)
+		for _, structure := range names {
+			fmt.Fprintf(w, "okxcct_spread_percent{symbol=%q,structure=%q} %g\n", symbol, structure, structures[structure])
+		}
+	}
+
+	fmt.Fprintln(w, "# HELP okxcct_actual_profit_percent Spread net of fees")
+	fmt.Fprintln(w, "# TYPE okxcct_actual_profit_percent gauge")
+	for _, symbol := range symbols {
+		fmt.Fprintf(w, "okxcct_actual_profit_percent{symbol=%q} %g\n", symbol, s.actualProfit[symbol])
+	}
+
+	fmt.Fprintln(w, "# HELP okxcct_funding_rate Last observed funding rate")
+	fmt.Fprintln(w, "# TYPE okxcct_funding_rate gauge")
+	for _, symbol := range symbols {
+		fmt.Fprintf(w, "okxcct_funding_rate{symbol=%q} %g\n", symbol, s.fundingRate[symbol])
+	}
+
+	writeHistogram(w, "okxcct_slippage_percent", "Weighted-price slippage", s.slippageSamples, []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1})
+	writeHistogram(w, "okxcct_orderbook_fetch_latency_seconds", "Order book fetch latency", s.fetchLatencySecs, []float64{0.05, 0.1, 0.25, 0.5, 1, 2, 5})
+}
+
+// multiSink fans a single write out to several OutputSinks, e.g. a
+// human-readable table plus a PrometheusSink scraped by an HTTP endpoint.
+type multiSink struct {
+	sinks []OutputSink
+}
+
+// NewMultiSink combines sinks so a single PrintRealArbitrageResults/
+// PrintTopMarkPxDiffsWithFundingAndFees call can write to all of them.
+func NewMultiSink(sinks ...OutputSink) OutputSink {
+	return &multiSink{sinks: sinks}
+}
+
+func (s *multiSink) WriteDiffs(rows []DiffRow, minDiff float64) error {
+	for _, sink := range s.sinks {
+		if err := sink.WriteDiffs(rows, minDiff); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *multiSink) WriteRealResults(rows []RealResultRow, minDiff float64) error {
+	for _, sink := range s.sinks {
+		if err := sink.WriteRealResults(rows, minDiff); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeHistogram(w io.Writer, name, help string, samples []float64, buckets []float64) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s histogram\n", name)
+	var sum float64
+	counts := make([]int, len(buckets))
+	for _, v := range samples {
+		sum += v
+		for i, b := range buckets {
+			if v <= b {
+				counts[i]++
+			}
+		}
+	}
+	for i, b := range buckets {
+		fmt.Fprintf(w, "%s_bucket{le=%q} %d\n", name, strconv.FormatFloat(b, 'f', -1, 64), counts[i])
+	}
+	fmt.Fprintf(w, "%s_bucket{le=\"+Inf\"} %d\n", name, len(samples))
+	fmt.Fprintf(w, "%s_sum %g\n", name, sum)
+	fmt.Fprintf(w, "%s_count %d\n", name, len(samples))
+}
@@ -0,0 +1,80 @@
+package gookx
+
+import "time"
+
+// FundingCadence is OKX's perpetual swap funding interval.
+const FundingCadence = 8 * time.Hour
+
+// NetPnLBreakdown decomposes an arbitrage opportunity's expected return
+// over a holding horizon into its constituent cashflows, instead of the
+// single lumped ActualProfit figure EstimateFees used to produce.
+type NetPnLBreakdown struct {
+	Spread   float64 // raw margin/swap percent difference
+	Funding  float64 // expected funding received (positive) or paid (negative) over the horizon
+	Borrow   float64 // prorated margin borrow interest on the backwardation leg
+	Fees     float64 // two-sided taker fees, entry and exit
+	Slippage float64 // expected weighted-price slippage
+	Net      float64 // Spread + Funding - Borrow - Fees - Slippage
+}
+
+// PnLModel estimates NetPnLBreakdown for a RealArbitrageResult over a
+// fixed holding horizon, replacing EstimateFees' single-hour borrow
+// assumption with horizon-aware borrow proration and funding-cycle-aware
+// funding cashflows.
+type PnLModel struct {
+	Horizon time.Duration
+}
+
+// NewPnLModel builds a PnLModel for the given holding horizon.
+func NewPnLModel(horizon time.Duration) PnLModel {
+	return PnLModel{Horizon: horizon}
+}
+
+// Estimate computes the net PnL breakdown for result, given the swap's
+// current funding context, the fee schedule, and per-symbol margin
+// borrow rates.
+func (m PnLModel) Estimate(result RealArbitrageResult, funding FundingSummary, fees FeeInfo, borrowRates map[string]float64) NetPnLBreakdown {
+	spread := result.PercentDiff
+
+	periods := fundingPeriodsInHorizon(funding.TimeToFunding, m.Horizon)
+	fundingPct := funding.Rate * 100 * float64(periods)
+	if !result.IsContango {
+		// Backwardation: long the swap, so a positive rate is paid out,
+		// not received.
+		fundingPct = -fundingPct
+	}
+
+	var borrowPct float64
+	if !result.IsContango {
+		// Backwardation sells margin, which requires borrowing the base
+		// currency; contango buys margin with owned/quote funds.
+		borrow := fees.MarginBorrow
+		if r, ok := borrowRates[result.BaseSymbol]; ok && r > 0 {
+			borrow = r
+		}
+		borrowPct = borrow * 100 * m.Horizon.Hours()
+	}
+
+	feesPct := (fees.SpotTaker*2 + fees.SwapTaker*2) * 100
+	slippagePct := (result.MarginSlippage + result.SwapSlippage) / 2
+
+	return NetPnLBreakdown{
+		Spread:   spread,
+		Funding:  fundingPct,
+		Borrow:   borrowPct,
+		Fees:     feesPct,
+		Slippage: slippagePct,
+		Net:      spread + fundingPct - borrowPct - feesPct - slippagePct,
+	}
+}
+
+// fundingPeriodsInHorizon returns how many funding events occur within
+// horizon: one for the upcoming event at untilNext (if it falls within
+// the horizon at all), plus one for every full FundingCadence after it.
+func fundingPeriodsInHorizon(untilNext, horizon time.Duration) int {
+	if horizon <= 0 || untilNext > horizon {
+		return 0
+	}
+	remaining := horizon - untilNext
+	return 1 + int(remaining/FundingCadence)
+}
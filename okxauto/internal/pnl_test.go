@@ -0,0 +1,30 @@
+package gookx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFundingPeriodsInHorizon(t *testing.T) {
+	cases := []struct {
+		name    string
+		until   time.Duration
+		horizon time.Duration
+		want    int
+	}{
+		{"next event outside horizon", 9 * time.Hour, 8 * time.Hour, 0},
+		{"non-positive horizon", time.Hour, 0, 0},
+		{"next event right at horizon edge", 8 * time.Hour, 8 * time.Hour, 1},
+		{"one event, no room for another", time.Hour, 4 * time.Hour, 1},
+		{"one extra full cadence after the first event", time.Hour, 9 * time.Hour, 2},
+		{"several extra cadences", time.Hour, 25 * time.Hour, 4},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := fundingPeriodsInHorizon(c.until, c.horizon); got != c.want {
+				t.Errorf("fundingPeriodsInHorizon(%v, %v) = %d, want %d", c.until, c.horizon, got, c.want)
+			}
+		})
+	}
+}
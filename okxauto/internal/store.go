@@ -0,0 +1,316 @@
+package gookx
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	_ "github.com/lib/pq" // driver registered for future use; see SQLStore's doc comment
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// Store persists scanner output so thresholds like -min-diff can be
+// tuned from empirical distributions instead of guessing.
+type Store interface {
+	RecordDiff(ctx context.Context, diff DiffResult) error
+	RecordInterestRate(ctx context.Context, asset string, principle, interest, rate float64) error
+	RecordFunding(ctx context.Context, instID string, rate float64, fundingTime int64) error
+	RecordPosition(ctx context.Context, pos Position) error
+	Close() error
+}
+
+// migration is a single rockhopper-style forward step: a name (for the
+// schema_migrations ledger) and the SQL to apply.
+type migration struct {
+	name string
+	sql  string
+}
+
+var migrations = []migration{
+	{
+		name: "0001_arbitrage_opportunities",
+		sql: `CREATE TABLE IF NOT EXISTS arbitrage_opportunities (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			base_symbol TEXT NOT NULL,
+			margin_px REAL NOT NULL,
+			swap_px REAL NOT NULL,
+			percent_diff REAL NOT NULL,
+			ts INTEGER NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_arbitrage_opportunities_symbol_ts
+			ON arbitrage_opportunities (base_symbol, ts);`,
+	},
+	{
+		name: "0002_margin_interest_history",
+		sql: `CREATE TABLE IF NOT EXISTS margin_interest_history (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			asset TEXT NOT NULL,
+			principle REAL NOT NULL,
+			interest REAL NOT NULL,
+			rate REAL NOT NULL,
+			ts INTEGER NOT NULL
+		);`,
+	},
+	{
+		name: "0003_funding_history",
+		sql: `CREATE TABLE IF NOT EXISTS funding_history (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			inst_id TEXT NOT NULL,
+			rate REAL NOT NULL,
+			funding_time INTEGER NOT NULL
+		);`,
+	},
+	{
+		name: "0004_positions",
+		sql: `CREATE TABLE IF NOT EXISTS positions (
+			base_symbol TEXT PRIMARY KEY,
+			avg_cost REAL NOT NULL,
+			qty REAL NOT NULL,
+			updated_at INTEGER NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_positions_updated_at ON positions (updated_at);`,
+	},
+	{
+		name: "0005_positions_realized_pnl",
+		sql:  `ALTER TABLE positions ADD COLUMN realized_pnl REAL NOT NULL DEFAULT 0;`,
+	},
+}
+
+// SQLStore is the default Store, backed by database/sql. The migrations
+// above use SQLite-specific syntax (AUTOINCREMENT, INSERT ... ON
+// CONFLICT DO UPDATE), so SQLite is the only driver this currently
+// supports; a MySQL or Postgres backend would need its own DDL and
+// upsert statements.
+type SQLStore struct {
+	db *sql.DB
+}
+
+func NewSQLStore(driverName, dsn string) (*SQLStore, error) {
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open store: %w", err)
+	}
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping store: %w", err)
+	}
+
+	store := &SQLStore{db: db}
+	if err := store.migrate(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate store: %w", err)
+	}
+	return store, nil
+}
+
+func (s *SQLStore) migrate() error {
+	if _, err := s.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		name TEXT PRIMARY KEY,
+		applied_at INTEGER NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	for _, m := range migrations {
+		var applied int
+		err := s.db.QueryRow(`SELECT COUNT(1) FROM schema_migrations WHERE name = ?`, m.name).Scan(&applied)
+		if err != nil {
+			return fmt.Errorf("failed to check migration %s: %w", m.name, err)
+		}
+		if applied > 0 {
+			continue
+		}
+		if _, err := s.db.Exec(m.sql); err != nil {
+			return fmt.Errorf("failed to apply migration %s: %w", m.name, err)
+		}
+		if _, err := s.db.Exec(`INSERT INTO schema_migrations (name, applied_at) VALUES (?, ?)`, m.name, time.Now().Unix()); err != nil {
+			return fmt.Errorf("failed to record migration %s: %w", m.name, err)
+		}
+	}
+	return nil
+}
+
+func (s *SQLStore) RecordDiff(ctx context.Context, diff DiffResult) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO arbitrage_opportunities (base_symbol, margin_px, swap_px, percent_diff, ts) VALUES (?, ?, ?, ?, ?)`,
+		diff.BaseSymbol, diff.MarginMarkPx, diff.SwapMarkPx, diff.PercentDiff, time.Now().Unix())
+	if err != nil {
+		return fmt.Errorf("failed to record diff: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLStore) RecordInterestRate(ctx context.Context, asset string, principle, interest, rate float64) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO margin_interest_history (asset, principle, interest, rate, ts) VALUES (?, ?, ?, ?, ?)`,
+		asset, principle, interest, rate, time.Now().Unix())
+	if err != nil {
+		return fmt.Errorf("failed to record interest history: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLStore) RecordFunding(ctx context.Context, instID string, rate float64, fundingTime int64) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO funding_history (inst_id, rate, funding_time) VALUES (?, ?, ?)`,
+		instID, rate, fundingTime)
+	if err != nil {
+		return fmt.Errorf("failed to record funding history: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLStore) RecordPosition(ctx context.Context, pos Position) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO positions (base_symbol, avg_cost, qty, realized_pnl, updated_at) VALUES (?, ?, ?, ?, ?)
+		 ON CONFLICT(base_symbol) DO UPDATE SET avg_cost = excluded.avg_cost, qty = excluded.qty, realized_pnl = excluded.realized_pnl, updated_at = excluded.updated_at`,
+		pos.Symbol, pos.AvgCost, pos.Qty, pos.RealizedPnL, time.Now().Unix())
+	if err != nil {
+		return fmt.Errorf("failed to record position: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLStore) Close() error {
+	return s.db.Close()
+}
+
+// SpreadHistoryPoint is a single recorded markPx-diff sample.
+type SpreadHistoryPoint struct {
+	TS          int64   `json:"ts"`
+	PercentDiff float64 `json:"percentDiff"`
+}
+
+// QuerySpreadHistory returns every recorded DiffResult for baseSymbol in
+// [from, to), ordered by time, for research/backtesting use.
+func (s *SQLStore) QuerySpreadHistory(ctx context.Context, baseSymbol string, from, to time.Time) ([]SpreadHistoryPoint, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT ts, percent_diff FROM arbitrage_opportunities WHERE base_symbol = ? AND ts >= ? AND ts < ? ORDER BY ts`,
+		baseSymbol, from.Unix(), to.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query spread history: %w", err)
+	}
+	defer rows.Close()
+
+	var points []SpreadHistoryPoint
+	for rows.Next() {
+		var p SpreadHistoryPoint
+		if err := rows.Scan(&p.TS, &p.PercentDiff); err != nil {
+			return nil, fmt.Errorf("failed to scan spread history row: %w", err)
+		}
+		points = append(points, p)
+	}
+	return points, rows.Err()
+}
+
+// SymbolStats summarizes a base symbol's recorded spread history for the
+// `stats` CLI subcommand.
+type SymbolStats struct {
+	BaseSymbol   string  `json:"baseSymbol"`
+	SampleCount  int     `json:"sampleCount"`
+	HitRate      float64 `json:"hitRate"` // percent of samples >= minDiff
+	MeanSpread   float64 `json:"meanSpread"`
+	MedianSpread float64 `json:"medianSpread"`
+}
+
+// SymbolStats computes per-symbol hit rate and mean/median spread from
+// recorded history, so users can pick -min-diff from an empirical
+// distribution instead of guessing.
+func (s *SQLStore) SymbolStats(ctx context.Context, baseSymbol string, from, to time.Time, minDiff float64) (SymbolStats, error) {
+	points, err := s.QuerySpreadHistory(ctx, baseSymbol, from, to)
+	if err != nil {
+		return SymbolStats{}, err
+	}
+	if len(points) == 0 {
+		return SymbolStats{BaseSymbol: baseSymbol}, nil
+	}
+
+	diffs := make([]float64, len(points))
+	var sum float64
+	var hits int
+	for i, p := range points {
+		diffs[i] = p.PercentDiff
+		sum += p.PercentDiff
+		if p.PercentDiff >= minDiff {
+			hits++
+		}
+	}
+	sort.Float64s(diffs)
+
+	return SymbolStats{
+		BaseSymbol:   baseSymbol,
+		SampleCount:  len(points),
+		HitRate:      100 * float64(hits) / float64(len(points)),
+		MeanSpread:   sum / float64(len(points)),
+		MedianSpread: median(diffs),
+	}, nil
+}
+
+func median(sorted []float64) float64 {
+	n := len(sorted)
+	if n == 0 {
+		return 0
+	}
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// DailyPnLSummary is one row of a Reporter's daily breakdown. RealizedPnL
+// is positions.realized_pnl as it stands today, not a per-day figure:
+// there is no fills ledger to reconstruct what was realized on each
+// historical day, so every day bucket for a symbol repeats its current
+// cumulative realized P&L.
+type DailyPnLSummary struct {
+	Day            string
+	BaseSymbol     string
+	OpportunityCnt int
+	AvgPercentDiff float64
+	RealizedPnL    float64
+}
+
+// Reporter joins recorded opportunities with each symbol's current
+// Position to produce daily opportunity-count/spread summaries for
+// backtesting thresholds, annotated with realized P&L to date.
+type Reporter struct {
+	db *sql.DB
+}
+
+func NewReporter(store *SQLStore) *Reporter {
+	return &Reporter{db: store.db}
+}
+
+// DailySummary returns one DailyPnLSummary per (day, base_symbol) in
+// [from, to), ordered by day then base symbol. See DailyPnLSummary's
+// doc comment for what RealizedPnL does and does not represent.
+func (r *Reporter) DailySummary(ctx context.Context, from, to time.Time) ([]DailyPnLSummary, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT
+			date(ts, 'unixepoch') AS day,
+			o.base_symbol,
+			COUNT(*) AS opportunity_cnt,
+			AVG(o.percent_diff) AS avg_percent_diff,
+			COALESCE(p.realized_pnl, 0) AS realized_pnl
+		FROM arbitrage_opportunities o
+		LEFT JOIN positions p ON p.base_symbol = o.base_symbol
+		WHERE o.ts >= ? AND o.ts < ?
+		GROUP BY day, o.base_symbol
+		ORDER BY day, o.base_symbol`,
+		from.Unix(), to.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query daily summary: %w", err)
+	}
+	defer rows.Close()
+
+	var summaries []DailyPnLSummary
+	for rows.Next() {
+		var s DailyPnLSummary
+		if err := rows.Scan(&s.Day, &s.BaseSymbol, &s.OpportunityCnt, &s.AvgPercentDiff, &s.RealizedPnL); err != nil {
+			return nil, fmt.Errorf("failed to scan daily summary row: %w", err)
+		}
+		summaries = append(summaries, s)
+	}
+	return summaries, rows.Err()
+}
@@ -0,0 +1,24 @@
+package gookx
+
+import "testing"
+
+func TestMedian(t *testing.T) {
+	cases := []struct {
+		name   string
+		sorted []float64
+		want   float64
+	}{
+		{"empty", nil, 0},
+		{"odd", []float64{1, 2, 3}, 2},
+		{"even", []float64{1, 2, 3, 4}, 2.5},
+		{"single", []float64{5}, 5},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := median(c.sorted); got != c.want {
+				t.Errorf("median(%v) = %v, want %v", c.sorted, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,391 @@
+package gookx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// defaultFillConfirmTimeout bounds how long ExecuteOpportunity waits for a
+// leg to clear orders-pending before treating it as unfilled.
+const defaultFillConfirmTimeout = 5 * time.Second
+
+// OrderStatus mirrors the subset of OKX order states the Trader cares about.
+type OrderStatus string
+
+const (
+	OrderStatusLive     OrderStatus = "live"
+	OrderStatusFilled   OrderStatus = "filled"
+	OrderStatusCanceled OrderStatus = "canceled"
+)
+
+// Order tracks a single order placed by the Trader.
+type Order struct {
+	OrdID      string
+	InstID     string
+	Side       string // "buy" or "sell"
+	Sz         float64
+	Px         float64
+	Status     OrderStatus
+	BaseSymbol string
+}
+
+// ActiveOrderBook tracks open orders and fans out fill/cancel events,
+// mirroring bbgo's activeorderbook pattern.
+type ActiveOrderBook struct {
+	mu     sync.Mutex
+	orders map[string]*Order
+
+	OnFilled   func(Order)
+	OnCanceled func(Order)
+}
+
+func NewActiveOrderBook() *ActiveOrderBook {
+	return &ActiveOrderBook{orders: make(map[string]*Order)}
+}
+
+func (b *ActiveOrderBook) Add(o Order) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.orders[o.OrdID] = &o
+}
+
+func (b *ActiveOrderBook) UpdateStatus(ordID string, status OrderStatus) {
+	b.mu.Lock()
+	order, ok := b.orders[ordID]
+	if !ok {
+		b.mu.Unlock()
+		return
+	}
+	order.Status = status
+	delete(b.orders, ordID)
+	b.mu.Unlock()
+
+	switch status {
+	case OrderStatusFilled:
+		if b.OnFilled != nil {
+			b.OnFilled(*order)
+		}
+	case OrderStatusCanceled:
+		if b.OnCanceled != nil {
+			b.OnCanceled(*order)
+		}
+	}
+}
+
+func (b *ActiveOrderBook) Open() []Order {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	open := make([]Order, 0, len(b.orders))
+	for _, o := range b.orders {
+		open = append(open, *o)
+	}
+	return open
+}
+
+// Position tracks realized/unrealized PnL for a single instrument leg
+// (e.g. the margin leg or the swap leg of a paired trade), analogous to
+// bbgo's AverageCostPnlReport.
+type Position struct {
+	Symbol        string
+	Qty           float64
+	AvgCost       float64
+	RealizedPnL   float64
+	UnrealizedPnL float64
+}
+
+// ApplyFill updates average cost and realized PnL for a fill on this side
+// of the position. A positive qty is a buy, negative is a sell.
+func (p *Position) ApplyFill(qty, price float64) {
+	if p.Qty == 0 || sameSign(p.Qty, qty) {
+		newQty := p.Qty + qty
+		if newQty != 0 {
+			p.AvgCost = (p.AvgCost*p.Qty + price*qty) / newQty
+		}
+		p.Qty = newQty
+		return
+	}
+
+	closing := qty
+	if abs(qty) > abs(p.Qty) {
+		closing = -p.Qty
+	}
+	p.RealizedPnL += closing * (p.AvgCost - price)
+	p.Qty += qty
+	if p.Qty == 0 {
+		p.AvgCost = 0
+	}
+}
+
+func (p *Position) MarkToMarket(markPx float64) {
+	p.UnrealizedPnL = p.Qty * (markPx - p.AvgCost)
+}
+
+func sameSign(a, b float64) bool {
+	return (a >= 0) == (b >= 0)
+}
+
+func abs(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// Trader consumes RealArbitrageResult values and places the paired
+// margin+swap orders needed to capture them. All order placement is
+// gated behind Config.LiveTrading; when false, orders are logged but not
+// sent to OKX (dry-run mode).
+type Trader struct {
+	client        *HTTPClient
+	config        Config
+	tradingConfig TradingConfig
+
+	activeOrders *ActiveOrderBook
+
+	positionsMu sync.Mutex
+	positions   map[string]*Position
+}
+
+func NewTrader(client *HTTPClient, config Config, tradingConfig TradingConfig) *Trader {
+	t := &Trader{
+		client:        client,
+		config:        config,
+		tradingConfig: tradingConfig,
+		activeOrders:  NewActiveOrderBook(),
+		positions:     make(map[string]*Position),
+	}
+	// A leg only applies to its Position once activeOrders confirms it
+	// actually filled (see confirmFilled), not as soon as placeOrder
+	// returns an order ID.
+	t.activeOrders.OnFilled = func(o Order) {
+		qty := o.Sz
+		if o.Side == "sell" {
+			qty = -qty
+		}
+		t.Position(o.InstID).ApplyFill(qty, o.Px)
+	}
+	return t
+}
+
+// Position returns the tracked Position for key (an instrument ID, one
+// per leg of a paired trade), creating it on first use.
+func (t *Trader) Position(key string) *Position {
+	t.positionsMu.Lock()
+	defer t.positionsMu.Unlock()
+	pos, ok := t.positions[key]
+	if !ok {
+		pos = &Position{Symbol: key}
+		t.positions[key] = pos
+	}
+	return pos
+}
+
+type orderRequest struct {
+	InstID  string `json:"instId"`
+	TdMode  string `json:"tdMode"`
+	Side    string `json:"side"`
+	OrdType string `json:"ordType"`
+	Sz      string `json:"sz"`
+}
+
+type orderResponse struct {
+	Code string `json:"code"`
+	Msg  string `json:"msg"`
+	Data []struct {
+		OrdID string `json:"ordId"`
+		SCode string `json:"sCode"`
+		SMsg  string `json:"sMsg"`
+	} `json:"data"`
+}
+
+// placeOrder signs and sends a single market order. In dry-run mode
+// (Config.LiveTrading == false) it logs the intended order and returns a
+// synthetic order ID instead of calling OKX.
+func (t *Trader) placeOrder(ctx context.Context, instID, tdMode, side string, sz float64) (string, error) {
+	if !t.config.LiveTrading {
+		log.Printf("[dry-run] would place %s order: instId=%s tdMode=%s sz=%.8f", side, instID, tdMode, sz)
+		return fmt.Sprintf("dryrun-%s-%s", instID, side), nil
+	}
+
+	req := orderRequest{
+		InstID:  instID,
+		TdMode:  tdMode,
+		Side:    side,
+		OrdType: "market",
+		Sz:      fmt.Sprintf("%.8f", sz),
+	}
+
+	body, err := t.client.MakeSignedPOST(ctx, "/api/v5/trade/order", req)
+	if err != nil {
+		return "", fmt.Errorf("place order failed: %w", err)
+	}
+
+	var resp orderResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", fmt.Errorf("failed to unmarshal order response: %w", err)
+	}
+	if resp.Code != "0" || len(resp.Data) == 0 {
+		return "", fmt.Errorf("order rejected: %s - %s", resp.Code, resp.Msg)
+	}
+	if resp.Data[0].SCode != "0" {
+		return "", fmt.Errorf("order rejected: %s - %s", resp.Data[0].SCode, resp.Data[0].SMsg)
+	}
+	return resp.Data[0].OrdID, nil
+}
+
+type borrowRequest struct {
+	Ccy  string `json:"ccy"`
+	Amt  string `json:"amt"`
+	Side string `json:"side"`
+}
+
+type borrowResponse struct {
+	Code string `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+// borrowMargin requests a margin borrow of amt ccy (ExecuteOpportunity
+// sizes it from TradeSizeUSD). interestRate is only written to the
+// dry-run log; it does not change the amount borrowed.
+func (t *Trader) borrowMargin(ctx context.Context, ccy string, amt float64, interestRate float64) error {
+	if !t.config.LiveTrading {
+		log.Printf("[dry-run] would borrow %.8f %s at rate %.6f/hr", amt, ccy, interestRate)
+		return nil
+	}
+
+	req := borrowRequest{Ccy: ccy, Amt: fmt.Sprintf("%.8f", amt), Side: "borrow"}
+	body, err := t.client.MakeSignedPOST(ctx, "/api/v5/account/borrow-repay", req)
+	if err != nil {
+		return fmt.Errorf("borrow request failed: %w", err)
+	}
+	var resp borrowResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("failed to unmarshal borrow response: %w", err)
+	}
+	if resp.Code != "0" {
+		return fmt.Errorf("borrow rejected: %s - %s", resp.Code, resp.Msg)
+	}
+	return nil
+}
+
+// ExecuteOpportunity places the paired margin+swap orders implied by a
+// RealArbitrageResult: for Contango, buy margin and sell swap; for
+// Backwardation, sell margin (requires a borrow) and buy swap. Each leg's
+// Position is only updated once confirmFilled observes it actually clear
+// orders-pending, not as soon as placeOrder returns an order ID. If the
+// swap leg cannot be placed after the margin leg has filled, the margin
+// leg is unwound with an opposing market order. A swap leg that is placed
+// but does not confirm filled is returned as an error and the margin leg
+// is left open.
+func (t *Trader) ExecuteOpportunity(ctx context.Context, result RealArbitrageResult, marginInstID, swapInstID, baseCcy string, interestRates map[string]float64) error {
+	var marginSide, swapSide string
+	var marginPrice, swapPrice float64
+	if result.IsContango {
+		marginSide, swapSide = "buy", "sell"
+		marginPrice, swapPrice = result.MarginBuyPrice, result.SwapSellPrice
+	} else {
+		marginSide, swapSide = "sell", "buy"
+		marginPrice, swapPrice = result.MarginSellPrice, result.SwapBuyPrice
+	}
+	tradeSizeBase := t.tradingConfig.TradeSizeUSD / marginPrice
+
+	if !result.IsContango {
+		if err := t.borrowMargin(ctx, baseCcy, tradeSizeBase, interestRates[baseCcy]); err != nil {
+			return fmt.Errorf("failed to borrow for backwardation leg: %w", err)
+		}
+	}
+
+	marginOrdID, err := t.placeOrder(ctx, marginInstID, "cross", marginSide, tradeSizeBase)
+	if err != nil {
+		return fmt.Errorf("failed to place margin leg: %w", err)
+	}
+	t.activeOrders.Add(Order{OrdID: marginOrdID, InstID: marginInstID, Side: marginSide, Sz: tradeSizeBase, Px: marginPrice, BaseSymbol: result.BaseSymbol})
+	if err := t.confirmFilled(ctx, marginOrdID); err != nil {
+		return fmt.Errorf("margin leg did not confirm filled: %w", err)
+	}
+
+	swapOrdID, err := t.placeOrder(ctx, swapInstID, "cross", swapSide, tradeSizeBase)
+	if err != nil {
+		rollbackSide := "sell"
+		if marginSide == "sell" {
+			rollbackSide = "buy"
+		}
+		if rollbackErr := t.rollbackMarginLeg(ctx, marginInstID, rollbackSide, tradeSizeBase); rollbackErr != nil {
+			return fmt.Errorf("failed to place swap leg: %w (rollback of margin leg also failed: %v)", err, rollbackErr)
+		}
+		return fmt.Errorf("failed to place swap leg: %w (margin leg rolled back)", err)
+	}
+	t.activeOrders.Add(Order{OrdID: swapOrdID, InstID: swapInstID, Side: swapSide, Sz: tradeSizeBase, Px: swapPrice, BaseSymbol: result.BaseSymbol})
+	if err := t.confirmFilled(ctx, swapOrdID); err != nil {
+		return fmt.Errorf("swap leg did not confirm filled: %w", err)
+	}
+
+	return nil
+}
+
+// confirmFilled waits for ordID to clear orders-pending, then marks it
+// filled (or canceled, on timeout) in activeOrders -- which dispatches
+// OnFilled/OnCanceled and applies or withholds the Position update. In
+// dry-run mode there is no real order to poll, so the synthetic order is
+// marked filled immediately.
+func (t *Trader) confirmFilled(ctx context.Context, ordID string) error {
+	if !t.config.LiveTrading {
+		t.activeOrders.UpdateStatus(ordID, OrderStatusFilled)
+		return nil
+	}
+	if err := t.waitFilled(ctx, ordID, defaultFillConfirmTimeout); err != nil {
+		t.activeOrders.UpdateStatus(ordID, OrderStatusCanceled)
+		return err
+	}
+	t.activeOrders.UpdateStatus(ordID, OrderStatusFilled)
+	return nil
+}
+
+// waitFilled polls orders-pending until ordID is no longer resting (filled
+// or canceled) or timeout elapses.
+func (t *Trader) waitFilled(ctx context.Context, ordID string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		orders, err := t.client.GetOpenOrders(ctx, "")
+		if err == nil {
+			stillOpen := false
+			for _, o := range orders {
+				if o.OrdID == ordID {
+					stillOpen = true
+					break
+				}
+			}
+			if !stillOpen {
+				return nil
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for order %s to fill", ordID)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// rollbackMarginLeg unwinds an already-filled margin leg with an
+// opposing market order of the same size, so a swap leg that could not
+// be placed does not leave the margin leg open.
+func (t *Trader) rollbackMarginLeg(ctx context.Context, marginInstID, side string, sz float64) error {
+	ordID, err := t.placeOrder(ctx, marginInstID, "cross", side, sz)
+	if err != nil {
+		return err
+	}
+	t.activeOrders.Add(Order{OrdID: ordID, InstID: marginInstID, Side: side, Sz: sz})
+	return nil
+}
@@ -0,0 +1,347 @@
+package gookx
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// TriangularPath is a 3-leg instrument cycle, e.g. [BTC-USDT, ETH-BTC, ETH-USDT].
+type TriangularPath [3]string
+
+// TriangularArbitrageResult describes a single evaluated triangular cycle.
+type TriangularArbitrageResult struct {
+	Path          TriangularPath `json:"path"`
+	GrossRatio    float64        `json:"grossRatio"` // round-trip conversion ratio before taker fees
+	NetRatio      float64        `json:"netRatio"`    // 1.0 = break-even, >1.0 = profitable, net of taker fees
+	Direction     string         `json:"direction"`    // "forward" or "reverse"
+	LegPrices     [3]float64     `json:"legPrices"`
+	MinLiquidity  float64        `json:"minLiquidity"`
+	TotalSlippage float64        `json:"totalSlippage"` // percent, summed across legs
+}
+
+// TriangularScanner evaluates a set of triangular paths using live order books
+// from an HTTPClient, netting out taker fees on every leg.
+type TriangularScanner struct {
+	client    *HTTPClient
+	fees      FeeInfo
+	tradeSize float64
+	depth     int
+}
+
+func NewTriangularScanner(client *HTTPClient, fees FeeInfo, tradeSizeUSD float64, depth int) *TriangularScanner {
+	return &TriangularScanner{
+		client:    client,
+		fees:      fees,
+		tradeSize: tradeSizeUSD,
+		depth:     depth,
+	}
+}
+
+// Scan fetches order books for every instrument in every path and returns the
+// profitable ones (NetRatio > 1), sorted by NetRatio descending.
+func (s *TriangularScanner) Scan(ctx context.Context, paths []TriangularPath) ([]TriangularArbitrageResult, error) {
+	var results []TriangularArbitrageResult
+
+	for _, path := range paths {
+		books := make(map[string]*OrderBook, 3)
+		for _, instID := range path {
+			book, err := s.client.FetchOrderBook(ctx, instID, s.depth)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch order book for %s: %w", instID, err)
+			}
+			books[instID] = book
+		}
+
+		result, ok := s.evaluate(path, books)
+		if !ok {
+			continue
+		}
+		results = append(results, result)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].NetRatio > results[j].NetRatio
+	})
+
+	return results, nil
+}
+
+// evaluate computes the forward and reverse round trip of a single path
+// and returns the more profitable direction. Each leg's instrument is
+// quoted as BASE-QUOTE (price = quote per base), so crossing a leg in the
+// base->quote direction multiplies by its weighted price (a "sell") while
+// crossing it quote->base divides by it (a "buy") — the raw leg prices
+// cannot simply be multiplied together, since they're denominated in
+// different currencies.
+func (s *TriangularScanner) evaluate(path TriangularPath, books map[string]*OrderBook) (TriangularArbitrageResult, bool) {
+	currencies, ok := cycleCurrencies(path)
+	if !ok {
+		return TriangularArbitrageResult{}, false
+	}
+
+	forward, forwardOK := s.legPrices(path, books, currencies, false)
+	reverse, reverseOK := s.legPrices(path, books, currencies, true)
+
+	takerFee := s.fees.SpotTaker
+	if takerFee == 0 {
+		takerFee = s.fees.SwapTaker
+	}
+	feeFactor := (1 - takerFee) * (1 - takerFee) * (1 - takerFee)
+
+	var best TriangularArbitrageResult
+	var found bool
+
+	if forwardOK {
+		ratio := forward.ratio * feeFactor
+		if ratio > 1 {
+			best = TriangularArbitrageResult{
+				Path:          path,
+				GrossRatio:    forward.ratio,
+				NetRatio:      ratio,
+				Direction:     "forward",
+				LegPrices:     forward.legs,
+				MinLiquidity:  forward.minLiquidity,
+				TotalSlippage: forward.totalSlippage,
+			}
+			found = true
+		}
+	}
+	if reverseOK {
+		ratio := reverse.ratio * feeFactor
+		if ratio > 1 && (!found || ratio > best.NetRatio) {
+			best = TriangularArbitrageResult{
+				Path:          path,
+				GrossRatio:    reverse.ratio,
+				NetRatio:      ratio,
+				Direction:     "reverse",
+				LegPrices:     reverse.legs,
+				MinLiquidity:  reverse.minLiquidity,
+				TotalSlippage: reverse.totalSlippage,
+			}
+			found = true
+		}
+	}
+
+	return best, found
+}
+
+type legEvaluation struct {
+	legs          [3]float64
+	ratio         float64 // round-trip conversion ratio, gross of fees
+	minLiquidity  float64
+	totalSlippage float64
+}
+
+// legPrices fetches a weighted execution price for each leg of path and
+// combines them into a round-trip conversion ratio along currencies (in
+// reverse order when reverse is true).
+func (s *TriangularScanner) legPrices(path TriangularPath, books map[string]*OrderBook, currencies [3][2]string, reverse bool) (legEvaluation, bool) {
+	var eval legEvaluation
+	eval.minLiquidity = -1
+	eval.ratio = 1
+
+	for i, instID := range path {
+		from, to := currencies[i][0], currencies[i][1]
+		if reverse {
+			from, to = to, from
+		}
+		side, invert, ok := legConversion(instID, from, to)
+		if !ok {
+			return legEvaluation{}, false
+		}
+
+		book, ok := books[instID]
+		if !ok {
+			return legEvaluation{}, false
+		}
+		wp, err := CalculateWeightedPrice(book, side, s.tradeSize)
+		if err != nil || !wp.HasEnoughLiquidity {
+			return legEvaluation{}, false
+		}
+		eval.legs[i] = wp.WeightedPrice
+		if invert {
+			eval.ratio /= wp.WeightedPrice
+		} else {
+			eval.ratio *= wp.WeightedPrice
+		}
+		eval.totalSlippage += wp.Slippage
+		if eval.minLiquidity < 0 || wp.Liquidity < eval.minLiquidity {
+			eval.minLiquidity = wp.Liquidity
+		}
+	}
+
+	return eval, true
+}
+
+// cycleCurrencies determines the currency each leg of path converts
+// between for the forward direction of the round trip, by finding the
+// currency each cyclically-adjacent pair of legs has in common. Returns
+// false if path isn't a genuine 3-currency triangle.
+func cycleCurrencies(path TriangularPath) ([3][2]string, bool) {
+	var legCcys [3][2]string
+	for i, instID := range path {
+		parts := splitInstID(instID)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return [3][2]string{}, false
+		}
+		legCcys[i] = [2]string{parts[0], parts[1]}
+	}
+
+	shared := func(a, b [2]string) (string, bool) {
+		for _, x := range a {
+			for _, y := range b {
+				if x == y {
+					return x, true
+				}
+			}
+		}
+		return "", false
+	}
+
+	hub01, ok := shared(legCcys[0], legCcys[1])
+	if !ok {
+		return [3][2]string{}, false
+	}
+	hub12, ok := shared(legCcys[1], legCcys[2])
+	if !ok {
+		return [3][2]string{}, false
+	}
+	hub20, ok := shared(legCcys[2], legCcys[0])
+	if !ok {
+		return [3][2]string{}, false
+	}
+	if hub01 == hub12 || hub12 == hub20 || hub01 == hub20 {
+		return [3][2]string{}, false // degenerate: fewer than 3 distinct currencies
+	}
+
+	return [3][2]string{
+		{hub20, hub01}, // leg0 converts hub20 -> hub01
+		{hub01, hub12}, // leg1 converts hub01 -> hub12
+		{hub12, hub20}, // leg2 converts hub12 -> hub20
+	}, true
+}
+
+// legConversion returns the order-book side and whether to invert the
+// weighted price to convert `from` into `to` across instID (BASE-QUOTE).
+// Going base->quote is a sell (multiply by price); going quote->base is
+// a buy (divide by price).
+func legConversion(instID, from, to string) (side string, invert, ok bool) {
+	parts := splitInstID(instID)
+	if len(parts) != 2 {
+		return "", false, false
+	}
+	base, quote := parts[0], parts[1]
+	switch {
+	case from == base && to == quote:
+		return "sell", false, true
+	case from == quote && to == base:
+		return "buy", true, true
+	default:
+		return "", false, false
+	}
+}
+
+// TriangularOpportunity is a pure-spot triangular cycle evaluated net of
+// three taker fees and the slippage incurred crossing all three legs,
+// ready to be ranked by actual (not just gross) profit.
+type TriangularOpportunity struct {
+	Path            TriangularPath `json:"path"`
+	RoundTripReturn float64        `json:"roundTripReturn"` // round-trip conversion ratio at weighted (slippage-inclusive) leg prices, before taker fees
+	NetProfit       float64        `json:"netProfit"`        // percent, net of 3x taker fee and total slippage
+}
+
+// ScanSpotBases discovers 3-cycles among SPOT instruments that touch one
+// of the given base currencies, evaluates each with Scan, and returns the
+// resulting opportunities sorted by NetProfit descending. This is the
+// pure-spot counterpart to evaluating user-supplied paths directly.
+func (s *TriangularScanner) ScanSpotBases(ctx context.Context, bases []string, spotInstruments []Instrument) ([]TriangularOpportunity, error) {
+	wanted := make(map[string]bool, len(bases))
+	for _, b := range bases {
+		wanted[b] = true
+	}
+
+	var candidatePaths []TriangularPath
+	for _, path := range DiscoverTriangularPaths(spotInstruments) {
+		if pathTouchesAnyBase(path, wanted) {
+			candidatePaths = append(candidatePaths, path)
+		}
+	}
+
+	results, err := s.Scan(ctx, candidatePaths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan spot base paths: %w", err)
+	}
+
+	opportunities := make([]TriangularOpportunity, 0, len(results))
+	for _, r := range results {
+		netProfit := (r.NetRatio - 1) * 100
+		opportunities = append(opportunities, TriangularOpportunity{
+			Path:            r.Path,
+			RoundTripReturn: r.GrossRatio,
+			NetProfit:       netProfit,
+		})
+	}
+
+	sort.Slice(opportunities, func(i, j int) bool {
+		return opportunities[i].NetProfit > opportunities[j].NetProfit
+	})
+
+	return opportunities, nil
+}
+
+func pathTouchesAnyBase(path TriangularPath, bases map[string]bool) bool {
+	for _, instID := range path {
+		base := ExtractBaseSymbol(instID)
+		if bases[base] {
+			return true
+		}
+	}
+	return false
+}
+
+// DiscoverTriangularPaths builds a currency graph from a list of SPOT
+// instruments and enumerates every 3-cycle that shares a common quote
+// currency with at least one leg, e.g. BTC-USDT, ETH-BTC, ETH-USDT.
+func DiscoverTriangularPaths(instruments []Instrument) []TriangularPath {
+	type edge struct {
+		instID string
+		base   string
+		quote  string
+	}
+
+	var edges []edge
+	for _, inst := range instruments {
+		if inst.InstType != "SPOT" {
+			continue
+		}
+		edges = append(edges, edge{instID: inst.InstID, base: inst.BaseCcy, quote: inst.QuoteCcy})
+	}
+
+	seen := make(map[TriangularPath]bool)
+	var paths []TriangularPath
+
+	for _, ab := range edges {
+		for _, bc := range edges {
+			if bc.base != ab.quote && bc.quote != ab.quote {
+				continue
+			}
+			thirdCcy := bc.base
+			if bc.base == ab.quote {
+				thirdCcy = bc.quote
+			}
+			for _, ca := range edges {
+				if (ca.base == thirdCcy && ca.quote == ab.base) || (ca.base == ab.base && ca.quote == thirdCcy) {
+					path := TriangularPath{ab.instID, bc.instID, ca.instID}
+					if seen[path] {
+						continue
+					}
+					seen[path] = true
+					paths = append(paths, path)
+				}
+			}
+		}
+	}
+
+	return paths
+}
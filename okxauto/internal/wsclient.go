@@ -0,0 +1,505 @@
+package gookx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	wsPublicURL      = "wss://ws.okx.com:8443/ws/v5/public"
+	wsPingInterval   = 25 * time.Second
+	wsMaxBackoff     = 30 * time.Second
+	wsInitialBackoff = 1 * time.Second
+)
+
+// OrderBookSource abstracts where an order book comes from so that
+// CalculateRealArbitrageOpportunities can transparently read either a
+// REST snapshot (HTTPClient) or a live streaming cache (WSClient).
+type OrderBookSource interface {
+	FetchOrderBook(ctx context.Context, instID string, depth int) (*OrderBook, error)
+}
+
+// WSClient maintains a live, incrementally-updated order book cache fed by
+// OKX's public WebSocket channels (books, books5, bbo-tbt, mark-price,
+// funding-rate, trades).
+type WSClient struct {
+	config Config
+
+	mu        sync.RWMutex
+	books     map[string]*OrderBook
+	marks     map[string]MarkPrice
+	funded    map[string]FundingInfo
+	lastTrade map[string]float64
+
+	subsMu sync.Mutex
+	subs   map[string][]chan *OrderBook
+
+	conn    *websocket.Conn
+	connMu  sync.Mutex
+	instIDs []string
+}
+
+func NewWSClient(config Config) *WSClient {
+	return &WSClient{
+		config:    config,
+		books:     make(map[string]*OrderBook),
+		marks:     make(map[string]MarkPrice),
+		funded:    make(map[string]FundingInfo),
+		lastTrade: make(map[string]float64),
+		subs:      make(map[string][]chan *OrderBook),
+	}
+}
+
+// Connect dials the public WebSocket endpoint, subscribes to the books,
+// mark-price, funding-rate, and trades channels for every instID, and
+// runs the read/reconnect loop until ctx is canceled.
+func (w *WSClient) Connect(ctx context.Context, instIDs []string) error {
+	w.instIDs = instIDs
+	go w.run(ctx)
+	return nil
+}
+
+// SubscribeOrderBook returns a channel that receives the live order book
+// for instID every time it changes. The channel is buffered so a slow
+// consumer drops updates rather than blocking the read loop; callers that
+// only need the latest snapshot should prefer LiveOrderBook.
+func (w *WSClient) SubscribeOrderBook(instID string) <-chan *OrderBook {
+	ch := make(chan *OrderBook, 16)
+	w.subsMu.Lock()
+	w.subs[instID] = append(w.subs[instID], ch)
+	w.subsMu.Unlock()
+	return ch
+}
+
+// LiveOrderBook returns the current cached snapshot for instID, or nil if
+// nothing has been received yet.
+func (w *WSClient) LiveOrderBook(instID string) *OrderBook {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	book, ok := w.books[instID]
+	if !ok {
+		return nil
+	}
+	clone := *book
+	return &clone
+}
+
+func (w *WSClient) notifySubscribers(instID string, book *OrderBook) {
+	w.subsMu.Lock()
+	defer w.subsMu.Unlock()
+	clone := *book
+	for _, ch := range w.subs[instID] {
+		select {
+		case ch <- &clone:
+		default:
+		}
+	}
+}
+
+func (w *WSClient) run(ctx context.Context) {
+	backoff := wsInitialBackoff
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		if err := w.connectAndServe(ctx); err != nil {
+			log.Printf("ws: connection error: %v, reconnecting in %s", err, backoff)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+			if backoff > wsMaxBackoff {
+				backoff = wsMaxBackoff
+			}
+			continue
+		}
+		backoff = wsInitialBackoff
+	}
+}
+
+func (w *WSClient) connectAndServe(ctx context.Context) error {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, wsPublicURL, nil)
+	if err != nil {
+		return fmt.Errorf("dial failed: %w", err)
+	}
+	defer conn.Close()
+
+	w.connMu.Lock()
+	w.conn = conn
+	w.connMu.Unlock()
+
+	if err := w.subscribe(conn, w.instIDs); err != nil {
+		return fmt.Errorf("subscribe failed: %w", err)
+	}
+
+	pingTicker := time.NewTicker(wsPingInterval)
+	defer pingTicker.Stop()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			w.handleMessage(msg)
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-done:
+			return fmt.Errorf("read loop ended")
+		case <-pingTicker.C:
+			if err := conn.WriteMessage(websocket.TextMessage, []byte("ping")); err != nil {
+				return fmt.Errorf("ping failed: %w", err)
+			}
+		}
+	}
+}
+
+func (w *WSClient) subscribe(conn *websocket.Conn, instIDs []string) error {
+	var args []map[string]string
+	for _, instID := range instIDs {
+		args = append(args, map[string]string{"channel": "books", "instId": instID})
+		args = append(args, map[string]string{"channel": "books5", "instId": instID})
+		args = append(args, map[string]string{"channel": "bbo-tbt", "instId": instID})
+		args = append(args, map[string]string{"channel": "mark-price", "instId": instID})
+		args = append(args, map[string]string{"channel": "funding-rate", "instId": instID})
+		args = append(args, map[string]string{"channel": "trades", "instId": instID})
+	}
+	req := map[string]interface{}{"op": "subscribe", "args": args}
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	return conn.WriteMessage(websocket.TextMessage, payload)
+}
+
+func (w *WSClient) resubscribe(instID string) {
+	w.connMu.Lock()
+	conn := w.conn
+	w.connMu.Unlock()
+	if conn == nil {
+		return
+	}
+	if err := w.subscribe(conn, []string{instID}); err != nil {
+		log.Printf("ws: failed to resubscribe %s: %v", instID, err)
+	}
+}
+
+type wsBookLevel = []string
+
+type wsEnvelope struct {
+	Arg struct {
+		Channel string `json:"channel"`
+		InstID  string `json:"instId"`
+	} `json:"arg"`
+	Action string            `json:"action"`
+	Data   []json.RawMessage `json:"data"`
+}
+
+func (w *WSClient) handleMessage(msg []byte) {
+	if string(msg) == "pong" {
+		return
+	}
+
+	var env wsEnvelope
+	if err := json.Unmarshal(msg, &env); err != nil {
+		return
+	}
+
+	switch env.Arg.Channel {
+	case "books":
+		w.handleBooksUpdate(env)
+	case "books5", "bbo-tbt":
+		w.handleBooksSnapshotOnly(env)
+	case "mark-price":
+		w.handleMarkPriceUpdate(env)
+	case "funding-rate":
+		w.handleFundingRateUpdate(env)
+	case "trades":
+		w.handleTradesUpdate(env)
+	}
+}
+
+// handleBooksSnapshotOnly handles books5 and bbo-tbt, both of which push a
+// full top-of-book snapshot on every message rather than snapshot+delta.
+// It only writes the cache when no book at all is cached yet for the
+// instrument, so it seeds the cache until the first "books" snapshot
+// arrives and never overwrites a cached book; later books5/bbo-tbt
+// messages for that instrument are ignored.
+func (w *WSClient) handleBooksSnapshotOnly(env wsEnvelope) {
+	instID := env.Arg.InstID
+
+	w.mu.RLock()
+	_, hasFullBook := w.books[instID]
+	w.mu.RUnlock()
+	if hasFullBook {
+		return
+	}
+
+	for _, raw := range env.Data {
+		var payload struct {
+			Bids []wsBookLevel `json:"bids"`
+			Asks []wsBookLevel `json:"asks"`
+			TS   string        `json:"ts"`
+		}
+		if err := json.Unmarshal(raw, &payload); err != nil {
+			continue
+		}
+		bids, _ := parseOrderBookLevels(payload.Bids)
+		asks, _ := parseOrderBookLevels(payload.Asks)
+		book := &OrderBook{InstID: instID, Bids: bids, Asks: asks, TS: payload.TS}
+
+		w.mu.Lock()
+		w.books[instID] = book
+		w.mu.Unlock()
+		w.notifySubscribers(instID, book)
+	}
+}
+
+func (w *WSClient) handleTradesUpdate(env wsEnvelope) {
+	for _, raw := range env.Data {
+		var payload struct {
+			Px string `json:"px"`
+		}
+		if err := json.Unmarshal(raw, &payload); err != nil {
+			continue
+		}
+		px, err := strconv.ParseFloat(payload.Px, 64)
+		if err != nil {
+			continue
+		}
+		w.mu.Lock()
+		w.lastTrade[env.Arg.InstID] = px
+		w.mu.Unlock()
+	}
+}
+
+// LastTradePrice returns the most recent traded price seen for instID on
+// the "trades" channel, or 0 if none has arrived yet.
+func (w *WSClient) LastTradePrice(instID string) float64 {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.lastTrade[instID]
+}
+
+func (w *WSClient) handleBooksUpdate(env wsEnvelope) {
+	for _, raw := range env.Data {
+		var payload struct {
+			Bids     []wsBookLevel `json:"bids"`
+			Asks     []wsBookLevel `json:"asks"`
+			TS       string        `json:"ts"`
+			Checksum int32         `json:"checksum"`
+		}
+		if err := json.Unmarshal(raw, &payload); err != nil {
+			continue
+		}
+
+		instID := env.Arg.InstID
+
+		switch env.Action {
+		case "snapshot":
+			bids, _ := parseOrderBookLevels(payload.Bids)
+			asks, _ := parseOrderBookLevels(payload.Asks)
+			w.mu.Lock()
+			w.books[instID] = &OrderBook{InstID: instID, Bids: bids, Asks: asks, TS: payload.TS}
+			w.mu.Unlock()
+		case "update":
+			w.mu.Lock()
+			book, ok := w.books[instID]
+			if !ok {
+				book = &OrderBook{InstID: instID}
+				w.books[instID] = book
+			}
+			applyBookDelta(book, payload.Bids, payload.Asks)
+			book.TS = payload.TS
+			w.mu.Unlock()
+		}
+
+		w.mu.RLock()
+		book := w.books[instID]
+		w.mu.RUnlock()
+		if book == nil {
+			continue
+		}
+		if !verifyBookChecksum(book, payload.Checksum) {
+			log.Printf("ws: checksum mismatch for %s, resubscribing", instID)
+			w.resubscribe(instID)
+			continue
+		}
+		w.notifySubscribers(instID, book)
+	}
+}
+
+// applyBookDelta merges per-level bid/ask updates into an existing book.
+// A level with size 0 removes that price; otherwise it's inserted/replaced
+// and the book is kept sorted (bids descending, asks ascending).
+func applyBookDelta(book *OrderBook, bidDeltas, askDeltas []wsBookLevel) {
+	book.Bids = mergeLevels(book.Bids, bidDeltas, true)
+	book.Asks = mergeLevels(book.Asks, askDeltas, false)
+}
+
+func mergeLevels(levels []OrderBookLevel, deltas []wsBookLevel, descending bool) []OrderBookLevel {
+	index := make(map[float64]int, len(levels))
+	for i, lvl := range levels {
+		index[lvl.Price] = i
+	}
+
+	for _, d := range deltas {
+		if len(d) < 2 {
+			continue
+		}
+		price, err := strconv.ParseFloat(d[0], 64)
+		if err != nil {
+			continue
+		}
+		size, err := strconv.ParseFloat(d[1], 64)
+		if err != nil {
+			continue
+		}
+
+		orders := 1
+		if len(d) >= 4 {
+			if o, err := strconv.Atoi(d[3]); err == nil {
+				orders = o
+			}
+		}
+
+		if i, ok := index[price]; ok {
+			if size == 0 {
+				levels = append(levels[:i], levels[i+1:]...)
+				delete(index, price)
+				for p, idx := range index {
+					if idx > i {
+						index[p] = idx - 1
+					}
+				}
+				continue
+			}
+			levels[i].Size = size
+			levels[i].Orders = orders
+			continue
+		}
+
+		if size == 0 {
+			continue
+		}
+		levels = append(levels, OrderBookLevel{Price: price, Size: size, Orders: orders})
+		index[price] = len(levels) - 1
+	}
+
+	sortLevels(levels, descending)
+	return levels
+}
+
+func sortLevels(levels []OrderBookLevel, descending bool) {
+	for i := 1; i < len(levels); i++ {
+		for j := i; j > 0; j-- {
+			var swap bool
+			if descending {
+				swap = levels[j].Price > levels[j-1].Price
+			} else {
+				swap = levels[j].Price < levels[j-1].Price
+			}
+			if !swap {
+				break
+			}
+			levels[j], levels[j-1] = levels[j-1], levels[j]
+		}
+	}
+}
+
+// verifyBookChecksum recomputes OKX's CRC32 checksum over the top 25
+// bid/ask price:size pairs and compares it against the server-provided
+// value from the update message.
+func verifyBookChecksum(book *OrderBook, want int32) bool {
+	var parts []string
+	for i := 0; i < 25; i++ {
+		if i < len(book.Bids) {
+			parts = append(parts, formatLevel(book.Bids[i]))
+		}
+		if i < len(book.Asks) {
+			parts = append(parts, formatLevel(book.Asks[i]))
+		}
+	}
+	got := int32(crc32.ChecksumIEEE([]byte(strings.Join(parts, ":"))))
+	return got == want
+}
+
+func formatLevel(lvl OrderBookLevel) string {
+	return strconv.FormatFloat(lvl.Price, 'f', -1, 64) + ":" + strconv.FormatFloat(lvl.Size, 'f', -1, 64)
+}
+
+func (w *WSClient) handleMarkPriceUpdate(env wsEnvelope) {
+	for _, raw := range env.Data {
+		var mp MarkPrice
+		if err := json.Unmarshal(raw, &mp); err != nil {
+			continue
+		}
+		w.mu.Lock()
+		w.marks[mp.InstID] = mp
+		w.mu.Unlock()
+	}
+}
+
+func (w *WSClient) handleFundingRateUpdate(env wsEnvelope) {
+	for _, raw := range env.Data {
+		var payload struct {
+			InstID          string `json:"instId"`
+			FundingRate     string `json:"fundingRate"`
+			NextFundingTime string `json:"nextFundingTime"`
+		}
+		if err := json.Unmarshal(raw, &payload); err != nil {
+			continue
+		}
+		rate, _ := strconv.ParseFloat(payload.FundingRate, 64)
+		nextTime, _ := strconv.ParseInt(payload.NextFundingTime, 10, 64)
+		w.mu.Lock()
+		w.funded[payload.InstID] = FundingInfo{InstID: payload.InstID, FundingRate: rate, NextFundingTime: nextTime}
+		w.mu.Unlock()
+	}
+}
+
+// FetchOrderBook satisfies OrderBookSource by reading from the live cache
+// instead of issuing a REST request. depth trims the returned levels.
+func (w *WSClient) FetchOrderBook(ctx context.Context, instID string, depth int) (*OrderBook, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	book, ok := w.books[instID]
+	if !ok {
+		return nil, fmt.Errorf("no live order book cached for %s", instID)
+	}
+
+	trimmed := &OrderBook{InstID: book.InstID, TS: book.TS}
+	if depth > 0 && depth < len(book.Bids) {
+		trimmed.Bids = append([]OrderBookLevel(nil), book.Bids[:depth]...)
+	} else {
+		trimmed.Bids = append([]OrderBookLevel(nil), book.Bids...)
+	}
+	if depth > 0 && depth < len(book.Asks) {
+		trimmed.Asks = append([]OrderBookLevel(nil), book.Asks[:depth]...)
+	} else {
+		trimmed.Asks = append([]OrderBookLevel(nil), book.Asks...)
+	}
+	return trimmed, nil
+}
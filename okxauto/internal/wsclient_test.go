@@ -0,0 +1,68 @@
+package gookx
+
+import (
+	"hash/crc32"
+	"strings"
+	"testing"
+)
+
+func TestMergeLevelsInsertUpdateRemove(t *testing.T) {
+	levels := []OrderBookLevel{
+		{Price: 100, Size: 1, Orders: 1},
+		{Price: 99, Size: 2, Orders: 1},
+	}
+
+	// Update 100's size, remove 99, insert 101.
+	deltas := []wsBookLevel{
+		{"100", "5", "0", "1"},
+		{"99", "0", "0", "0"},
+		{"101", "3", "0", "2"},
+	}
+
+	got := mergeLevels(levels, deltas, true)
+
+	want := []OrderBookLevel{
+		{Price: 101, Size: 3, Orders: 2},
+		{Price: 100, Size: 5, Orders: 1},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("mergeLevels returned %d levels, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("level %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestMergeLevelsAscendingSort(t *testing.T) {
+	deltas := []wsBookLevel{
+		{"101", "1", "0"},
+		{"99", "1", "0"},
+		{"100", "1", "0"},
+	}
+	got := mergeLevels(nil, deltas, false)
+	for i := 1; i < len(got); i++ {
+		if got[i].Price < got[i-1].Price {
+			t.Fatalf("ascending asks not sorted: %+v", got)
+		}
+	}
+}
+
+func TestVerifyBookChecksum(t *testing.T) {
+	book := &OrderBook{
+		InstID: "BTC-USDT",
+		Bids:   []OrderBookLevel{{Price: 100, Size: 1}},
+		Asks:   []OrderBookLevel{{Price: 101, Size: 2}},
+	}
+
+	parts := []string{formatLevel(book.Bids[0]), formatLevel(book.Asks[0])}
+	want := int32(crc32.ChecksumIEEE([]byte(strings.Join(parts, ":"))))
+
+	if !verifyBookChecksum(book, want) {
+		t.Error("verifyBookChecksum should accept a checksum computed the same way")
+	}
+	if verifyBookChecksum(book, want+1) {
+		t.Error("verifyBookChecksum should reject a mismatched checksum")
+	}
+}